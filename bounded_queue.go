@@ -0,0 +1,196 @@
+package dot
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+)
+
+// OverflowPolicy - BoundedQueue behavior on Push into the full queue
+type OverflowPolicy int
+
+const (
+	OverflowBlock      OverflowPolicy = iota // waits for free space
+	OverflowDropOldest                       // drops the oldest value to make room for the new one
+	OverflowDropNewest                       // drops the value being pushed
+	OverflowError                            // returns ErrQueueFull
+)
+
+var (
+	ErrQueueFull   = errors.New("queue is full")
+	ErrQueueClosed = errors.New("queue is closed")
+)
+
+// BoundedQueue - concurrency-safe FIFO queue with fixed capacity.
+// The zero value is an empty queue with capacity 1 and OverflowBlock policy.
+type BoundedQueue[T any] struct {
+	mx      sync.Mutex
+	items   ring[T]
+	policy  OverflowPolicy
+	closed  bool
+	changed chan struct{} // closed and replaced on every state change, wakes up waiters
+}
+
+// NewBoundedQueue - makes queue with specified capacity (at least 1) and overflow policy
+func NewBoundedQueue[T any](capacity int, policy OverflowPolicy) *BoundedQueue[T] {
+	return &BoundedQueue[T]{
+		items:   makeRing[T](capacity),
+		policy:  policy,
+		changed: make(chan struct{}),
+	}
+}
+
+// Push - appends value, applying overflow policy if the queue is full.
+// Returns ErrQueueClosed after Close, ErrQueueFull for OverflowError policy
+// and ctx error if ctx is done while waiting for OverflowBlock policy.
+func (q *BoundedQueue[T]) Push(ctx context.Context, val T) error {
+	for {
+		q.mx.Lock()
+		wait, err := q.push(val, q.policy)
+		q.mx.Unlock()
+
+		if wait == nil {
+			return err
+		}
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// TryPush - appends value without waiting: OverflowBlock policy returns ErrQueueFull for the full queue
+func (q *BoundedQueue[T]) TryPush(val T) error {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	_, err := q.push(val, Iif(q.policy == OverflowBlock, OverflowError, q.policy))
+
+	return err
+}
+
+// Pop - removes and returns the oldest value, waiting for it if the queue is empty.
+// Returns ErrQueueClosed for the closed and empty queue and ctx error if ctx is done while waiting.
+func (q *BoundedQueue[T]) Pop(ctx context.Context) (val T, err error) {
+	for {
+		q.mx.Lock()
+		q.lazyInit()
+		if q.items.length > 0 {
+			val = q.items.pop()
+			q.signal()
+			q.mx.Unlock()
+			return val, nil
+		}
+		closed, wait := q.closed, q.changed
+		q.mx.Unlock()
+
+		if closed {
+			return val, ErrQueueClosed
+		}
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return val, ctx.Err()
+		}
+	}
+}
+
+// TryPop - removes and returns the oldest value without waiting, false for the empty queue
+func (q *BoundedQueue[T]) TryPop() (val T, ok bool) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	if q.items.length == 0 {
+		return val, false
+	}
+	val = q.items.pop()
+	q.signal()
+
+	return val, true
+}
+
+// Close - forbids pushing. Values pushed before Close still can be popped.
+func (q *BoundedQueue[T]) Close() {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	q.lazyInit()
+	if !q.closed {
+		q.closed = true
+		q.signal()
+	}
+}
+
+func (q *BoundedQueue[T]) Len() int {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	return q.items.length
+}
+
+func (q *BoundedQueue[T]) Cap() int {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+	q.lazyInit()
+
+	return len(q.items.buf)
+}
+
+// Seq - pops values until the queue is closed and drained
+func (q *BoundedQueue[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			val, err := q.Pop(context.Background())
+			if err != nil || !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// push applies policy, must be called under the lock.
+// Returns channel to wait on, if the value must be pushed after the state change.
+func (q *BoundedQueue[T]) push(val T, policy OverflowPolicy) (wait <-chan struct{}, err error) {
+	if q.closed {
+		return nil, ErrQueueClosed
+	}
+	q.lazyInit()
+
+	if q.items.full() {
+		switch policy {
+		case OverflowBlock:
+			return q.changed, nil
+		case OverflowDropOldest:
+			q.items.pop()
+		case OverflowDropNewest:
+			return nil, nil
+		default: // OverflowError
+			return nil, ErrQueueFull
+		}
+	}
+
+	q.items.push(val)
+	q.signal()
+
+	return nil, nil
+}
+
+// lazyInit prepares the zero value queue, must be called under the lock
+func (q *BoundedQueue[T]) lazyInit() {
+	if q.items.buf == nil {
+		q.items = makeRing[T](1)
+	}
+	if q.changed == nil {
+		q.changed = make(chan struct{})
+	}
+}
+
+// signal wakes up all waiters, must be called under the lock
+func (q *BoundedQueue[T]) signal() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
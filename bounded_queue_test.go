@@ -0,0 +1,170 @@
+package dot_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirrorru/dot"
+)
+
+func TestBoundedQueue_Policies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		policy  dot.OverflowPolicy
+		expect  []int
+		pushErr error
+	}{
+		{name: "drop oldest", policy: dot.OverflowDropOldest, expect: []int{2, 3}},
+		{name: "drop newest", policy: dot.OverflowDropNewest, expect: []int{1, 2}},
+		{name: "error", policy: dot.OverflowError, expect: []int{1, 2}, pushErr: dot.ErrQueueFull},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := dot.NewBoundedQueue[int](2, tt.policy)
+			require.NoError(t, q.Push(t.Context(), 1))
+			require.NoError(t, q.Push(t.Context(), 2))
+			require.ErrorIs(t, q.Push(t.Context(), 3), tt.pushErr)
+			assert.Equal(t, 2, q.Len())
+			assert.Equal(t, 2, q.Cap())
+
+			q.Close()
+			assert.Equal(t, tt.expect, slices.Collect(q.Seq()))
+		})
+	}
+}
+
+func TestBoundedQueue_ZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var q dot.BoundedQueue[int]
+	assert.Equal(t, 1, q.Cap())
+	require.NoError(t, q.Push(context.Background(), 1))
+	require.ErrorIs(t, q.TryPush(2), dot.ErrQueueFull)
+
+	val, ok := q.TryPop()
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+
+	var waiting dot.BoundedQueue[int]
+	popped := make(chan int)
+	go func() {
+		val, _ := waiting.Pop(context.Background())
+		popped <- val
+	}()
+	require.NoError(t, waiting.Push(context.Background(), 7))
+	assert.Equal(t, 7, <-popped)
+
+	var closed dot.BoundedQueue[int]
+	closed.Close()
+	_, ok = closed.TryPop()
+	assert.False(t, ok)
+	require.ErrorIs(t, closed.Push(context.Background(), 1), dot.ErrQueueClosed)
+}
+
+func TestBoundedQueue_Block(t *testing.T) {
+	t.Parallel()
+
+	q := dot.NewBoundedQueue[int](1, dot.OverflowBlock)
+	require.NoError(t, q.TryPush(1))
+	require.ErrorIs(t, q.TryPush(2), dot.ErrQueueFull)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, q.Push(ctx, 2), context.DeadlineExceeded)
+
+	pushed := make(chan error)
+	go func() {
+		pushed <- q.Push(t.Context(), 3)
+	}()
+
+	val, err := q.Pop(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+	require.NoError(t, <-pushed)
+
+	val, ok := q.TryPop()
+	assert.True(t, ok)
+	assert.Equal(t, 3, val)
+	_, ok = q.TryPop()
+	assert.False(t, ok)
+}
+
+func TestBoundedQueue_PopWait(t *testing.T) {
+	t.Parallel()
+
+	q := dot.NewBoundedQueue[int](1, dot.OverflowBlock)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	_, err := q.Pop(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	popped := make(chan int)
+	go func() {
+		val, _ := q.Pop(t.Context())
+		popped <- val
+	}()
+	require.NoError(t, q.Push(t.Context(), 5))
+	assert.Equal(t, 5, <-popped)
+
+	q.Close()
+	_, err = q.Pop(t.Context())
+	require.ErrorIs(t, err, dot.ErrQueueClosed)
+	require.ErrorIs(t, q.Push(t.Context(), 1), dot.ErrQueueClosed)
+	require.ErrorIs(t, q.TryPush(1), dot.ErrQueueClosed)
+}
+
+func TestBoundedQueue_ProducersConsumers(t *testing.T) {
+	t.Parallel()
+
+	const (
+		producers   = 5
+		perProducer = 200
+	)
+
+	q := dot.NewBoundedQueue[int](8, dot.OverflowBlock)
+
+	var consumed dot.SyncSlice[int]
+	var consumers sync.WaitGroup
+	for range 3 {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for val := range q.Seq() {
+				consumed.Append(val)
+			}
+		}()
+	}
+
+	var prods sync.WaitGroup
+	for p := range producers {
+		prods.Add(1)
+		go func() {
+			defer prods.Done()
+			for i := range perProducer {
+				assert.NoError(t, q.Push(t.Context(), p*perProducer+i))
+			}
+		}()
+	}
+	prods.Wait()
+	q.Close()
+	consumers.Wait()
+
+	values := consumed.Values()
+	slices.Sort(values)
+	assert.Len(t, values, producers*perProducer)
+	for i, v := range values {
+		assert.Equal(t, i, v)
+	}
+}
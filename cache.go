@@ -0,0 +1,434 @@
+package dot
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// EvictionPolicy - rule of choosing entry to evict from the full Cache
+type EvictionPolicy int
+
+const (
+	EvictLRU EvictionPolicy = iota // evicts least recently used entry
+	EvictLFU                       // evicts least frequently used entry, least recently used among equals
+)
+
+// EvictReason - reason of entry eviction passed to eviction callback
+type EvictReason int
+
+const (
+	EvictReasonExpired  EvictReason = iota + 1 // entry TTL is over
+	EvictReasonCapacity                        // cache reached max entries count
+)
+
+// CacheStats - cache usage counters
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// CacheOption - Cache configuration option for NewCache
+type CacheOption[K comparable, V any] func(cfg *cacheConfig[K, V])
+
+type cacheConfig[K comparable, V any] struct {
+	ttl        time.Duration
+	maxEntries int
+	policy     EvictionPolicy
+	now        func() time.Time
+	onEvict    func(key K, val V, reason EvictReason)
+}
+
+// WithCacheTTL - sets default TTL of entries. Zero TTL means entries never expire.
+func WithCacheTTL[K comparable, V any](ttl time.Duration) CacheOption[K, V] {
+	return func(cfg *cacheConfig[K, V]) {
+		cfg.ttl = ttl
+	}
+}
+
+// WithCacheMaxEntries - limits count of entries. Zero means no limit.
+func WithCacheMaxEntries[K comparable, V any](maxEntries int) CacheOption[K, V] {
+	return func(cfg *cacheConfig[K, V]) {
+		cfg.maxEntries = maxEntries
+	}
+}
+
+// WithCachePolicy - sets eviction policy used when max entries count is reached
+func WithCachePolicy[K comparable, V any](policy EvictionPolicy) CacheOption[K, V] {
+	return func(cfg *cacheConfig[K, V]) {
+		cfg.policy = policy
+	}
+}
+
+// WithCacheClock - sets time source, time.Now by default
+func WithCacheClock[K comparable, V any](now func() time.Time) CacheOption[K, V] {
+	return func(cfg *cacheConfig[K, V]) {
+		cfg.now = now
+	}
+}
+
+// WithCacheOnEvict - sets callback called for every evicted entry.
+// Callback is called without holding the cache lock.
+func WithCacheOnEvict[K comparable, V any](handler func(key K, val V, reason EvictReason)) CacheOption[K, V] {
+	return func(cfg *cacheConfig[K, V]) {
+		cfg.onEvict = handler
+	}
+}
+
+// Cache - concurrency-safe key-value cache with entries TTL and bounded size.
+// Method names and GetOrPut semantics follow SyncStore.
+// It keeps own map and heaps instead of wrapping SyncStore,
+// because eviction must update them and the entries map under a single lock.
+type Cache[K comparable, V any] struct {
+	mx      sync.Mutex
+	cfg     cacheConfig[K, V]
+	entries map[K]*cacheEntry[K, V]
+	queue   cacheQueue[K, V]    // entries by eviction priority
+	expiry  cacheQueue[K, V]    // entries with TTL by expiration time
+	calls   map[K]*storeCall[V] // constructions in progress, see GetOrPutErr
+	tick    uint64
+	stats   CacheStats
+}
+
+type cacheEntry[K comparable, V any] struct {
+	key         K
+	val         V
+	expiresAt   time.Time // zero for entries without TTL
+	freq        uint64    // count of accesses
+	tick        uint64    // time of last access in cache ticks
+	index       int       // position in queue
+	expiryIndex int       // position in expiry, -1 for entries without TTL
+}
+
+func NewCache[K comparable, V any](opts ...CacheOption[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{
+		cfg: cacheConfig[K, V]{
+			policy: EvictLRU,
+			now:    time.Now,
+		},
+		entries: make(map[K]*cacheEntry[K, V]),
+		expiry:  cacheQueue[K, V]{byExpiry: true},
+	}
+	for _, opt := range opts {
+		opt(&c.cfg)
+	}
+	c.queue.policy = c.cfg.policy
+
+	return c
+}
+
+// Put - stores value with default TTL
+func (c *Cache[K, V]) Put(key K, val V) {
+	c.PutWithTTL(key, val, c.cfg.ttl)
+}
+
+// PutWithTTL - stores value with specified TTL. Zero TTL means the entry never expires.
+func (c *Cache[K, V]) PutWithTTL(key K, val V, ttl time.Duration) {
+	c.mx.Lock()
+	expired, evicted := c.put(key, val, ttl)
+	c.mx.Unlock()
+
+	c.notify(expired, EvictReasonExpired)
+	c.notify(evicted, EvictReasonCapacity)
+}
+
+// GetCurrent - returns not expired value of key
+func (c *Cache[K, V]) GetCurrent(key K) (val V, founded bool) {
+	c.mx.Lock()
+	val, founded, expired := c.get(key)
+	c.mx.Unlock()
+
+	c.notify(expired, EvictReasonExpired)
+
+	return val, founded
+}
+
+// GetOrPut - returns value of key, makes and stores it by maker if key is absent or expired.
+// See GetOrPutErr for concurrency details.
+func (c *Cache[K, V]) GetOrPut(key K, maker func() V) V {
+	return c.GetOrPutErr(key, func() (V, error) {
+		return maker(), nil
+	}).Val()
+}
+
+// GetOrPutErr - returns value of key, makes and stores it with default TTL by maker if key is absent or expired.
+// maker is called without holding the cache lock, so other keys stay available during construction.
+// Concurrent calls for the same key wait for the single maker call and share its result.
+// Errors are not stored, so the next call makes a new attempt.
+// A panic in maker is re-raised in every waiting caller.
+func (c *Cache[K, V]) GetOrPutErr(key K, maker func() (V, error)) Result[V] {
+	c.mx.Lock()
+	val, founded, expired := c.get(key)
+	if founded {
+		c.mx.Unlock()
+		return MakeResult(val, nil)
+	}
+
+	call, inProgress := c.calls[key]
+	if !inProgress {
+		call = &storeCall[V]{done: make(chan struct{})}
+		if c.calls == nil {
+			c.calls = make(map[K]*storeCall[V])
+		}
+		c.calls[key] = call
+	}
+	c.mx.Unlock()
+
+	c.notify(expired, EvictReasonExpired)
+
+	if inProgress {
+		<-call.done
+	} else {
+		c.makeCall(key, call, maker)
+	}
+
+	if call.panicked {
+		panic(call.panicVal)
+	}
+
+	return MakeResult(call.val, call.err)
+}
+
+// Del - deletes key without calling eviction callback
+func (c *Cache[K, V]) Del(key K) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if entry, ok := c.entries[key]; ok {
+		c.remove(entry)
+	}
+}
+
+// DeleteExpired - evicts all expired entries and returns their count
+func (c *Cache[K, V]) DeleteExpired() int {
+	c.mx.Lock()
+	expired := c.evictExpired(len(c.entries))
+	c.mx.Unlock()
+
+	c.notify(expired, EvictReasonExpired)
+
+	return len(expired)
+}
+
+// Len - returns count of entries, including expired ones not evicted yet
+func (c *Cache[K, V]) Len() int {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	return len(c.entries)
+}
+
+// Stats - returns usage counters
+func (c *Cache[K, V]) Stats() CacheStats {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	return c.stats
+}
+
+func (c *Cache[K, V]) makeCall(key K, call *storeCall[V], maker func() (V, error)) {
+	var expired, evicted []*cacheEntry[K, V]
+	defer func() {
+		if r := recover(); r != nil {
+			call.panicked, call.panicVal = true, r
+		}
+
+		c.mx.Lock()
+		delete(c.calls, key)
+		if !call.panicked && call.err == nil {
+			if entry, ok := c.entries[key]; ok && !c.isExpired(entry) {
+				// value was put while maker worked
+				call.val = entry.val
+			} else {
+				expired, evicted = c.put(key, call.val, c.cfg.ttl)
+			}
+		}
+		c.mx.Unlock()
+
+		close(call.done)
+		c.notify(expired, EvictReasonExpired)
+		c.notify(evicted, EvictReasonCapacity)
+	}()
+
+	call.val, call.err = maker()
+}
+
+// put stores value, then evicts entries over max entries count:
+// expired ones first, then by eviction policy
+func (c *Cache[K, V]) put(key K, val V, ttl time.Duration) (expired, evicted []*cacheEntry[K, V]) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.cfg.now().Add(ttl)
+	}
+
+	entry, ok := c.entries[key]
+	if ok {
+		entry.val = val
+		c.setExpiration(entry, expiresAt)
+		c.touch(entry)
+		return nil, nil
+	}
+
+	c.tick++
+	entry = &cacheEntry[K, V]{key: key, val: val, freq: 1, tick: c.tick, expiryIndex: -1}
+	c.entries[key] = entry
+	heap.Push(&c.queue, entry)
+	c.setExpiration(entry, expiresAt)
+
+	if c.cfg.maxEntries <= 0 || len(c.entries) <= c.cfg.maxEntries {
+		return nil, nil
+	}
+
+	expired = c.evictExpired(len(c.entries) - c.cfg.maxEntries)
+	for len(c.entries) > c.cfg.maxEntries {
+		victim := c.queue.items[0]
+		if victim == entry && len(c.queue.items) > 1 {
+			// never evict just stored entry while there is another one
+			victim = c.queue.minChild(0)
+		}
+		c.remove(victim)
+		evicted = append(evicted, victim)
+	}
+	c.stats.Evictions += uint64(len(evicted))
+
+	return expired, evicted
+}
+
+// evictExpired removes up to limit expired entries in order of expiration
+func (c *Cache[K, V]) evictExpired(limit int) (expired []*cacheEntry[K, V]) {
+	for len(expired) < limit && len(c.expiry.items) > 0 && c.isExpired(c.expiry.items[0]) {
+		entry := c.expiry.items[0]
+		c.remove(entry)
+		expired = append(expired, entry)
+	}
+	c.stats.Evictions += uint64(len(expired))
+
+	return expired
+}
+
+func (c *Cache[K, V]) get(key K) (val V, founded bool, expired []*cacheEntry[K, V]) {
+	entry, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		return val, false, nil
+	}
+
+	if c.isExpired(entry) {
+		c.remove(entry)
+		c.stats.Misses++
+		c.stats.Evictions++
+		return val, false, []*cacheEntry[K, V]{entry}
+	}
+
+	c.stats.Hits++
+	c.touch(entry)
+
+	return entry.val, true, nil
+}
+
+func (c *Cache[K, V]) touch(entry *cacheEntry[K, V]) {
+	c.tick++
+	entry.tick = c.tick
+	entry.freq++
+	heap.Fix(&c.queue, entry.index)
+}
+
+// setExpiration updates expiration time of entry and its position in expiry heap
+func (c *Cache[K, V]) setExpiration(entry *cacheEntry[K, V], expiresAt time.Time) {
+	entry.expiresAt = expiresAt
+	switch {
+	case expiresAt.IsZero() && entry.expiryIndex >= 0:
+		heap.Remove(&c.expiry, entry.expiryIndex)
+	case expiresAt.IsZero():
+	case entry.expiryIndex >= 0:
+		heap.Fix(&c.expiry, entry.expiryIndex)
+	default:
+		heap.Push(&c.expiry, entry)
+	}
+}
+
+func (c *Cache[K, V]) remove(entry *cacheEntry[K, V]) {
+	heap.Remove(&c.queue, entry.index)
+	if entry.expiryIndex >= 0 {
+		heap.Remove(&c.expiry, entry.expiryIndex)
+	}
+	delete(c.entries, entry.key)
+}
+
+func (c *Cache[K, V]) isExpired(entry *cacheEntry[K, V]) bool {
+	return !entry.expiresAt.IsZero() && !c.cfg.now().Before(entry.expiresAt)
+}
+
+func (c *Cache[K, V]) notify(evicted []*cacheEntry[K, V], reason EvictReason) {
+	if c.cfg.onEvict == nil {
+		return
+	}
+	for _, entry := range evicted {
+		c.cfg.onEvict(entry.key, entry.val, reason)
+	}
+}
+
+// cacheQueue - heap of entries ordered by eviction priority or by expiration time
+type cacheQueue[K comparable, V any] struct {
+	policy   EvictionPolicy
+	byExpiry bool // orders by expiration time and maintains expiryIndex instead of index
+	items    []*cacheEntry[K, V]
+}
+
+func (q *cacheQueue[K, V]) Len() int {
+	return len(q.items)
+}
+
+func (q *cacheQueue[K, V]) Less(i, j int) bool {
+	a, b := q.items[i], q.items[j]
+	if q.byExpiry {
+		return a.expiresAt.Before(b.expiresAt)
+	}
+	if q.policy == EvictLFU && a.freq != b.freq {
+		return a.freq < b.freq
+	}
+
+	return a.tick < b.tick
+}
+
+func (q *cacheQueue[K, V]) Swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.setIndex(i)
+	q.setIndex(j)
+}
+
+func (q *cacheQueue[K, V]) Push(x any) {
+	q.items = append(q.items, x.(*cacheEntry[K, V])) //nolint:forcetypeassert
+	q.setIndex(len(q.items) - 1)
+}
+
+func (q *cacheQueue[K, V]) Pop() any {
+	last := len(q.items) - 1
+	entry := q.items[last]
+	q.items[last] = nil
+	q.items = q.items[:last]
+	if q.byExpiry {
+		entry.expiryIndex = -1
+	}
+
+	return entry
+}
+
+func (q *cacheQueue[K, V]) setIndex(i int) {
+	if q.byExpiry {
+		q.items[i].expiryIndex = i
+	} else {
+		q.items[i].index = i
+	}
+}
+
+// minChild - returns the smaller child of heap node i
+func (q *cacheQueue[K, V]) minChild(i int) *cacheEntry[K, V] {
+	left, right := 2*i+1, 2*i+2
+	if right < len(q.items) && q.Less(right, left) {
+		return q.items[right]
+	}
+
+	return q.items[left]
+}
@@ -0,0 +1,227 @@
+package dot_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirrorru/dot"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+type evicted struct {
+	key    string
+	val    int
+	reason dot.EvictReason
+}
+
+func TestCache_Basic(t *testing.T) {
+	t.Parallel()
+
+	c := dot.NewCache[string, int]()
+	_, ok := c.GetCurrent("a")
+	assert.False(t, ok)
+
+	c.Put("a", 1)
+	v, ok := c.GetCurrent("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	assert.Equal(t, 1, c.GetOrPut("a", func() int { return 2 }))
+	assert.Equal(t, 3, c.GetOrPut("b", func() int { return 3 }))
+	assert.Equal(t, 2, c.Len())
+
+	c.Del("a")
+	_, ok = c.GetCurrent("a")
+	assert.False(t, ok)
+
+	assert.Equal(t, dot.CacheStats{Hits: 2, Misses: 3}, c.Stats())
+}
+
+func TestCache_TTL(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	var events []evicted
+	c := dot.NewCache(
+		dot.WithCacheTTL[string, int](time.Minute),
+		dot.WithCacheClock[string, int](clock.Now),
+		dot.WithCacheOnEvict(func(key string, val int, reason dot.EvictReason) {
+			events = append(events, evicted{key: key, val: val, reason: reason})
+		}),
+	)
+
+	c.Put("a", 1)
+	c.PutWithTTL("b", 2, time.Hour)
+	c.PutWithTTL("c", 3, 0)
+
+	clock.Advance(59 * time.Second)
+	_, ok := c.GetCurrent("a")
+	assert.True(t, ok)
+
+	clock.Advance(time.Second)
+	_, ok = c.GetCurrent("a")
+	assert.False(t, ok)
+	assert.Equal(t, []evicted{{key: "a", val: 1, reason: dot.EvictReasonExpired}}, events)
+
+	clock.Advance(time.Hour)
+	assert.Equal(t, 1, c.DeleteExpired())
+	assert.Equal(t, 1, c.Len())
+	_, ok = c.GetCurrent("c")
+	assert.True(t, ok)
+
+	assert.Equal(t, dot.CacheStats{Hits: 2, Misses: 1, Evictions: 2}, c.Stats())
+}
+
+func TestCache_Eviction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		policy dot.EvictionPolicy
+		expect []evicted
+	}{
+		{
+			name:   "LRU",
+			policy: dot.EvictLRU,
+			expect: []evicted{{key: "b", val: 2, reason: dot.EvictReasonCapacity}},
+		},
+		{
+			name:   "LFU",
+			policy: dot.EvictLFU,
+			expect: []evicted{{key: "c", val: 3, reason: dot.EvictReasonCapacity}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var events []evicted
+			c := dot.NewCache(
+				dot.WithCacheMaxEntries[string, int](3),
+				dot.WithCachePolicy[string, int](tt.policy),
+				dot.WithCacheOnEvict(func(key string, val int, reason dot.EvictReason) {
+					events = append(events, evicted{key: key, val: val, reason: reason})
+				}),
+			)
+
+			c.Put("a", 1)
+			c.Put("b", 2)
+			c.Put("c", 3)
+			// "a" is used most often, "b" is used more often than "c", but earlier
+			for range 3 {
+				c.GetCurrent("a")
+			}
+			c.GetCurrent("b")
+			c.GetCurrent("b")
+			c.GetCurrent("c")
+			c.GetCurrent("a")
+
+			c.Put("d", 4)
+			assert.Equal(t, tt.expect, events)
+			assert.Equal(t, 3, c.Len())
+			_, ok := c.GetCurrent("d")
+			assert.True(t, ok)
+			assert.Equal(t, uint64(1), c.Stats().Evictions)
+		})
+	}
+}
+
+func TestCache_LFUKeepsNewEntry(t *testing.T) {
+	t.Parallel()
+
+	c := dot.NewCache(dot.WithCacheMaxEntries[string, int](2), dot.WithCachePolicy[string, int](dot.EvictLFU))
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.GetCurrent("a")
+	c.GetCurrent("b")
+
+	c.Put("c", 3)
+	_, ok := c.GetCurrent("c")
+	assert.True(t, ok)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestCache_EvictsExpiredBeforeLive(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	var events []evicted
+	c := dot.NewCache(
+		dot.WithCacheMaxEntries[string, int](3),
+		dot.WithCacheClock[string, int](clock.Now),
+		dot.WithCacheOnEvict(func(key string, val int, reason dot.EvictReason) {
+			events = append(events, evicted{key: key, val: val, reason: reason})
+		}),
+	)
+
+	c.Put("live", 1) // the least recently used one
+	c.PutWithTTL("short", 2, time.Second)
+	c.PutWithTTL("long", 3, time.Hour)
+	c.PutWithTTL("long", 3, time.Second) // TTL update moves entry in expiration order
+	clock.Advance(time.Minute)
+
+	c.Put("new", 4)
+	assert.Equal(t, []evicted{{key: "short", val: 2, reason: dot.EvictReasonExpired}}, events)
+	_, ok := c.GetCurrent("live")
+	assert.True(t, ok)
+
+	c.PutWithTTL("long", 3, 0) // TTL removal keeps entry from expiration
+	c.Put("newer", 5)
+	assert.Equal(t, evicted{key: "new", val: 4, reason: dot.EvictReasonCapacity}, events[1])
+	assert.Equal(t, 3, c.Len())
+	assert.Equal(t, uint64(2), c.Stats().Evictions)
+}
+
+func TestCache_GetOrPutErr(t *testing.T) {
+	t.Parallel()
+
+	c := dot.NewCache[string, int]()
+
+	res := c.GetOrPutErr("a", func() (int, error) { return 0, assert.AnError })
+	require.ErrorIs(t, res.Err(), assert.AnError)
+	_, ok := c.GetCurrent("a")
+	assert.False(t, ok, "errors must not be stored")
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			val := c.GetOrPut("a", func() int {
+				calls.Add(1)
+				<-release
+				return 1
+			})
+			assert.Equal(t, 1, val)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load(), "concurrent calls must share single maker call")
+
+	assert.Panics(t, func() {
+		c.GetOrPut("p", func() int { panic("boom") })
+	})
+	assert.Equal(t, 2, c.GetOrPut("p", func() int { return 2 }))
+}
@@ -0,0 +1,179 @@
+package dot
+
+import (
+	"cmp"
+	"container/heap"
+	"slices"
+)
+
+// Comparator - three-way comparison: negative if a goes before b, positive if after, zero if equal.
+// It can be passed to slices.SortFunc and other functions of slices package.
+type Comparator[T any] func(a, b T) int
+
+// By - compares values by key in ascending order
+func By[T any, K cmp.Ordered](key func(T) K) Comparator[T] {
+	return func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// NilsFirst - compares values by pointer key in ascending order of pointed values, nil keys go first
+func NilsFirst[T any, K cmp.Ordered](key func(T) *K) Comparator[T] {
+	return func(a, b T) int {
+		return comparePtr(key(a), key(b), -1)
+	}
+}
+
+// NilsLast - compares values by pointer key in ascending order of pointed values, nil keys go last
+func NilsLast[T any, K cmp.Ordered](key func(T) *K) Comparator[T] {
+	return func(a, b T) int {
+		return comparePtr(key(a), key(b), 1)
+	}
+}
+
+// comparePtr compares pointed values, nilOrder is the result for nil a and not nil b
+func comparePtr[K cmp.Ordered](a, b *K, nilOrder int) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return nilOrder
+	case b == nil:
+		return -nilOrder
+	default:
+		return cmp.Compare(*a, *b)
+	}
+}
+
+// ThenBy - compares values by next if they are equal by c
+func (c Comparator[T]) ThenBy(next Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if res := c(a, b); res != 0 {
+			return res
+		}
+		return next(a, b)
+	}
+}
+
+// Reverse - inverts order of c
+func (c Comparator[T]) Reverse() Comparator[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// SortedBy - returns sorted copy of slice
+func SortedBy[T any](source []T, c Comparator[T]) []T {
+	if source == nil {
+		return nil
+	}
+
+	result := slices.Clone(source)
+	slices.SortFunc(result, c)
+
+	return result
+}
+
+// SortedStableBy - returns sorted copy of slice, equal values keep their order
+func SortedStableBy[T any](source []T, c Comparator[T]) []T {
+	if source == nil {
+		return nil
+	}
+
+	result := slices.Clone(source)
+	slices.SortStableFunc(result, c)
+
+	return result
+}
+
+// TopK - returns up to k values going first in order of c, sorted. Equal values keep their order.
+// Uses a heap of k values, so it is cheaper than sorting for small k.
+func TopK[T any](source []T, k int, c Comparator[T]) []T {
+	if source == nil {
+		return nil
+	}
+
+	k = max(min(k, len(source)), 0)
+	if k == 0 {
+		return []T{}
+	}
+
+	h := &topKHeap[T]{cmp: c, items: make([]topKItem[T], 0, k)}
+	for i, v := range source {
+		item := topKItem[T]{val: v, index: i}
+		switch {
+		case len(h.items) < k:
+			heap.Push(h, item)
+		case h.before(item, h.items[0]):
+			h.items[0] = item
+			heap.Fix(h, 0)
+		}
+	}
+
+	result := make([]T, len(h.items))
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(h).(topKItem[T]).val //nolint:forcetypeassert
+	}
+
+	return result
+}
+
+// MinBy - returns the first of minimal values, false for empty slice
+func MinBy[T any](source []T, c Comparator[T]) (val T, ok bool) {
+	if len(source) == 0 {
+		return val, false
+	}
+
+	return slices.MinFunc(source, c), true
+}
+
+// MaxBy - returns the first of maximal values, false for empty slice
+func MaxBy[T any](source []T, c Comparator[T]) (val T, ok bool) {
+	if len(source) == 0 {
+		return val, false
+	}
+
+	return slices.MaxFunc(source, c), true
+}
+
+type topKItem[T any] struct {
+	val   T
+	index int // position in source, orders equal values
+}
+
+// topKHeap keeps the last selected value on top
+type topKHeap[T any] struct {
+	cmp   Comparator[T]
+	items []topKItem[T]
+}
+
+func (h *topKHeap[T]) before(a, b topKItem[T]) bool {
+	if res := h.cmp(a.val, b.val); res != 0 {
+		return res < 0
+	}
+	return a.index < b.index
+}
+
+func (h *topKHeap[T]) Len() int {
+	return len(h.items)
+}
+
+func (h *topKHeap[T]) Less(i, j int) bool {
+	return h.before(h.items[j], h.items[i])
+}
+
+func (h *topKHeap[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *topKHeap[T]) Push(x any) {
+	h.items = append(h.items, x.(topKItem[T])) //nolint:forcetypeassert
+}
+
+func (h *topKHeap[T]) Pop() any {
+	last := len(h.items) - 1
+	item := h.items[last]
+	h.items = h.items[:last]
+
+	return item
+}
@@ -0,0 +1,96 @@
+package dot_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mirrorru/dot"
+)
+
+type person struct {
+	Name string
+	Age  int
+	Rank *int
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+var people = []person{
+	{Name: "bob", Age: 30, Rank: ptr(2)},
+	{Name: "ann", Age: 25},
+	{Name: "cid", Age: 30, Rank: ptr(1)},
+	{Name: "dan", Age: 25, Rank: ptr(3)},
+}
+
+func names(ps []person) []string {
+	return dot.SliceToSlice(ps, func(p person) string { return p.Name })
+}
+
+func TestComparator(t *testing.T) {
+	t.Parallel()
+
+	byAge := dot.By(func(p person) int { return p.Age })
+	byName := dot.By(func(p person) string { return p.Name })
+	rank := func(p person) *int { return p.Rank }
+
+	assert.Equal(t, []string{"ann", "dan", "bob", "cid"}, names(dot.SortedBy(people, byAge.ThenBy(byName))))
+	assert.Equal(t, []string{"bob", "cid", "ann", "dan"},
+		names(dot.SortedBy(people, byAge.Reverse().ThenBy(byName))))
+	assert.Equal(t, []string{"ann", "cid", "bob", "dan"}, names(dot.SortedBy(people, dot.NilsFirst(rank))))
+	assert.Equal(t, []string{"cid", "bob", "dan", "ann"}, names(dot.SortedBy(people, dot.NilsLast(rank))))
+	assert.Equal(t, []string{"bob", "ann", "cid", "dan"}, names(people), "source must not be changed")
+
+	assert.Equal(t, []string{"ann", "dan", "bob", "cid"}, names(dot.SortedStableBy(people, byAge)))
+	assert.Nil(t, dot.SortedBy(nil, byAge))
+	assert.Nil(t, dot.SortedStableBy(nil, byAge))
+
+	sorted := slices.Clone(people)
+	slices.SortFunc(sorted, byName)
+	assert.Equal(t, []string{"ann", "bob", "cid", "dan"}, names(sorted))
+}
+
+func TestMinMaxBy(t *testing.T) {
+	t.Parallel()
+
+	byAge := dot.By(func(p person) int { return p.Age })
+
+	minVal, ok := dot.MinBy(people, byAge)
+	assert.True(t, ok)
+	assert.Equal(t, "ann", minVal.Name)
+
+	maxVal, ok := dot.MaxBy(people, byAge)
+	assert.True(t, ok)
+	assert.Equal(t, "bob", maxVal.Name)
+
+	_, ok = dot.MinBy(nil, byAge)
+	assert.False(t, ok)
+	_, ok = dot.MaxBy([]person{}, byAge)
+	assert.False(t, ok)
+}
+
+func TestTopK(t *testing.T) {
+	t.Parallel()
+
+	byAge := dot.By(func(p person) int { return p.Age })
+	assert.Equal(t, []string{"ann", "dan", "bob"}, names(dot.TopK(people, 3, byAge)))
+	assert.Equal(t, []string{"bob", "cid"}, names(dot.TopK(people, 2, byAge.Reverse())))
+	assert.Equal(t, []string{"ann", "dan", "bob", "cid"}, names(dot.TopK(people, 10, byAge)))
+	assert.Equal(t, []person{}, dot.TopK(people, 0, byAge))
+	assert.Equal(t, []person{}, dot.TopK(people, -1, byAge))
+	assert.Nil(t, dot.TopK(nil, 2, byAge))
+
+	rnd := rand.New(rand.NewPCG(1, 2))
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = rnd.IntN(100)
+	}
+	byVal := dot.By(func(v int) int { return v })
+	for _, k := range []int{1, 7, 100, 999} {
+		assert.Equal(t, dot.SortedStableBy(values, byVal)[:k], dot.TopK(values, k, byVal))
+	}
+}
@@ -0,0 +1,227 @@
+package dot
+
+import (
+	"iter"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// CowSlice - copy-on-write slice for read-heavy workloads.
+// Reads and iteration are lock-free and work with the immutable values version,
+// every write copies the values under the writers lock. Method names follow SyncSlice.
+type CowSlice[T any] struct {
+	mx     sync.Mutex // serializes writers
+	values atomic.Pointer[[]T]
+}
+
+func NewCowSlice[T any](values ...T) *CowSlice[T] {
+	s := &CowSlice[T]{}
+	if len(values) > 0 {
+		values = slices.Clone(values)
+		s.values.Store(&values)
+	}
+
+	return s
+}
+
+func (s *CowSlice[T]) Len() int {
+	return len(s.load())
+}
+
+func (s *CowSlice[T]) Get(index int) T {
+	return s.load()[index]
+}
+
+// TryGet - returns value by index, false for index out of range
+func (s *CowSlice[T]) TryGet(index int) (val T, ok bool) {
+	values := s.load()
+	if index < 0 || index >= len(values) {
+		return val, false
+	}
+
+	return values[index], true
+}
+
+// Values - returns copy of values
+func (s *CowSlice[T]) Values() []T {
+	return slices.Clone(s.load())
+}
+
+// IndexFunc - returns index of the first value matched by f, -1 if none
+func (s *CowSlice[T]) IndexFunc(f func(val T) bool) int {
+	return slices.IndexFunc(s.load(), f)
+}
+
+// ContainsFunc - reports whether any value is matched by f
+func (s *CowSlice[T]) ContainsFunc(f func(val T) bool) bool {
+	return s.IndexFunc(f) >= 0
+}
+
+// Seq - iterates over values version actual at the start of iteration, without copying
+func (s *CowSlice[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s.load() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2 - iterates over indexes and values version actual at the start of iteration, without copying
+func (s *CowSlice[T]) Seq2() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range s.load() {
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
+func (s *CowSlice[T]) Append(val T) {
+	s.AppendAll(val)
+}
+
+// AppendAll - appends all values with a single copy
+func (s *CowSlice[T]) AppendAll(vals ...T) {
+	s.modify(func(values []T) ([]T, bool) {
+		return append(values, vals...), true
+	})
+}
+
+func (s *CowSlice[T]) Set(index int, val T) {
+	s.modify(func(values []T) ([]T, bool) {
+		values[index] = val
+		return values, true
+	})
+}
+
+// Insert - inserts values at index, false for index out of [0, Len()]
+func (s *CowSlice[T]) Insert(index int, vals ...T) bool {
+	return s.modify(func(values []T) ([]T, bool) {
+		if index < 0 || index > len(values) {
+			return nil, false
+		}
+		return slices.Insert(values, index, vals...), true
+	})
+}
+
+// RemoveAt - removes and returns value by index, false for index out of range
+func (s *CowSlice[T]) RemoveAt(index int) (val T, ok bool) {
+	ok = s.modify(func(values []T) ([]T, bool) {
+		if index < 0 || index >= len(values) {
+			return nil, false
+		}
+		val = values[index]
+		return slices.Delete(values, index, index+1), true
+	})
+
+	return val, ok
+}
+
+// Pop - removes and returns the last value, false for empty slice
+func (s *CowSlice[T]) Pop() (val T, ok bool) {
+	ok = s.modify(func(values []T) ([]T, bool) {
+		if len(values) == 0 {
+			return nil, false
+		}
+		val = values[len(values)-1]
+		return values[:len(values)-1], true
+	})
+
+	return val, ok
+}
+
+// Update - replaces value by index with result of updater, false for index out of range.
+// updater is called under the writers lock and must not modify the slice.
+func (s *CowSlice[T]) Update(index int, updater func(val T) T) bool {
+	return s.modify(func(values []T) ([]T, bool) {
+		if index < 0 || index >= len(values) {
+			return nil, false
+		}
+		values[index] = updater(values[index])
+		return values, true
+	})
+}
+
+// Swap - swaps values by indexes, false for any index out of range
+func (s *CowSlice[T]) Swap(i, j int) bool {
+	return s.modify(func(values []T) ([]T, bool) {
+		if i < 0 || i >= len(values) || j < 0 || j >= len(values) {
+			return nil, false
+		}
+		values[i], values[j] = values[j], values[i]
+		return values, true
+	})
+}
+
+// Truncate - shrinks slice to specified length, does nothing if length is not less than Len()
+func (s *CowSlice[T]) Truncate(length int) {
+	s.modify(func(values []T) ([]T, bool) {
+		if length < 0 || length >= len(values) {
+			return nil, false
+		}
+		return values[:length], true
+	})
+}
+
+// Clear - removes all values
+func (s *CowSlice[T]) Clear() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.values.Store(nil)
+}
+
+// DrainAll - returns all values and resets the slice
+func (s *CowSlice[T]) DrainAll() []T {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	values := s.values.Swap(nil)
+	if values == nil {
+		return nil
+	}
+
+	// readers may still iterate over the swapped version
+	return slices.Clone(*values)
+}
+
+// Filter - keeps only values matched by keep and returns count of removed values.
+// keep is called under the writers lock and must not modify the slice.
+func (s *CowSlice[T]) Filter(keep func(val T) bool) (removed int) {
+	s.modify(func(values []T) ([]T, bool) {
+		length := len(values)
+		values = slices.DeleteFunc(values, func(val T) bool {
+			return !keep(val)
+		})
+		removed = length - len(values)
+		return values, removed > 0
+	})
+
+	return removed
+}
+
+func (s *CowSlice[T]) load() []T {
+	values := s.values.Load()
+	if values == nil {
+		return nil
+	}
+
+	return *values
+}
+
+// modify calls change with a copy of values and publishes the result if change returns true
+func (s *CowSlice[T]) modify(change func(values []T) ([]T, bool)) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	values, ok := change(slices.Clone(s.load()))
+	if ok {
+		s.values.Store(&values)
+	}
+
+	return ok
+}
@@ -0,0 +1,163 @@
+package dot_test
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mirrorru/dot"
+)
+
+func TestCowSlice_ReadWrite(t *testing.T) {
+	t.Parallel()
+
+	s := dot.NewCowSlice(1, 2)
+	s.Append(3)
+	s.AppendAll(4, 5)
+	assert.Equal(t, 5, s.Len())
+	assert.Equal(t, 3, s.Get(2))
+
+	s.Set(0, 10)
+	assert.True(t, s.Insert(1, 11))
+	assert.False(t, s.Insert(10, 0))
+	assert.True(t, s.Update(1, func(v int) int { return v + 1 }))
+	assert.False(t, s.Update(10, func(v int) int { return v }))
+	assert.True(t, s.Swap(0, 1))
+	assert.False(t, s.Swap(0, 10))
+	assert.Equal(t, []int{12, 10, 2, 3, 4, 5}, s.Values())
+
+	val, ok := s.TryGet(5)
+	assert.True(t, ok)
+	assert.Equal(t, 5, val)
+	_, ok = s.TryGet(6)
+	assert.False(t, ok)
+
+	val, ok = s.RemoveAt(1)
+	assert.True(t, ok)
+	assert.Equal(t, 10, val)
+	_, ok = s.RemoveAt(10)
+	assert.False(t, ok)
+
+	val, ok = s.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 5, val)
+
+	assert.Equal(t, 1, s.Filter(func(v int) bool { return v%2 == 0 }))
+	assert.Equal(t, []int{12, 2, 4}, s.Values())
+	assert.Equal(t, 1, s.IndexFunc(func(v int) bool { return v < 10 }))
+	assert.False(t, s.ContainsFunc(func(v int) bool { return v > 100 }))
+
+	s.Truncate(2)
+	assert.Equal(t, []int{12, 2}, slices.Collect(s.Seq()))
+	assert.Equal(t, []int{12, 2}, s.DrainAll())
+	assert.Equal(t, 0, s.Len())
+	_, ok = s.Pop()
+	assert.False(t, ok)
+
+	s.AppendAll(1, 2)
+	s.Clear()
+	assert.Empty(t, s.Values())
+}
+
+func TestCowSlice_IterationIsolation(t *testing.T) {
+	t.Parallel()
+
+	s := dot.NewCowSlice(1, 2, 3)
+	var seen []int
+	for i, v := range s.Seq2() {
+		if i == 0 {
+			s.Set(1, 20)
+			s.Append(4)
+		}
+		seen = append(seen, v)
+	}
+	assert.Equal(t, []int{1, 2, 3}, seen)
+	assert.Equal(t, []int{1, 20, 3, 4}, s.Values())
+}
+
+func TestCowSlice_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	s := dot.NewCowSlice[int]()
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				s.Append(i)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				for range s.Seq() { //nolint:revive
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1000, s.Len())
+}
+
+type benchSlice interface {
+	Append(val int)
+	Set(index int, val int)
+	Seq() func(yield func(int) bool)
+}
+
+type benchSyncSlice struct {
+	dot.SyncSlice[int]
+}
+
+func (s *benchSyncSlice) Seq() func(yield func(int) bool) {
+	return s.SyncSlice.Seq()
+}
+
+type benchCowSlice struct {
+	*dot.CowSlice[int]
+}
+
+func (s benchCowSlice) Seq() func(yield func(int) bool) {
+	return s.CowSlice.Seq()
+}
+
+func BenchmarkSlices(b *testing.B) {
+	const size = 256
+
+	slicesToTest := []struct {
+		name string
+		make func() benchSlice
+	}{
+		{name: "SyncSlice", make: func() benchSlice { return &benchSyncSlice{} }},
+		{name: "CowSlice", make: func() benchSlice { return benchCowSlice{dot.NewCowSlice[int]()} }},
+	}
+
+	for _, writePercent := range []int{0, 1, 10, 50} {
+		for _, st := range slicesToTest {
+			b.Run(fmt.Sprintf("writes=%d%%/%s", writePercent, st.name), func(b *testing.B) {
+				s := st.make()
+				for i := range size {
+					s.Append(i)
+				}
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					i := 0
+					for pb.Next() {
+						if i%100 < writePercent {
+							s.Set(i%size, i) // keeps length fixed
+						} else {
+							for range s.Seq() { //nolint:revive
+							}
+						}
+						i++
+					}
+				})
+			})
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package dot
+
+import (
+	"iter"
+	"sync"
+)
+
+// Deque - double-ended queue over growing ring buffer, not concurrency-safe, see SyncDeque.
+// Zero value is ready to use.
+type Deque[T any] struct {
+	items ring[T]
+}
+
+func NewDeque[T any](capacity int) *Deque[T] {
+	return &Deque[T]{items: makeRing[T](capacity)}
+}
+
+func (d *Deque[T]) PushBack(val T) {
+	if d.items.full() {
+		d.items.grow()
+	}
+	d.items.push(val)
+}
+
+func (d *Deque[T]) PushFront(val T) {
+	if d.items.full() {
+		d.items.grow()
+	}
+	d.items.pushFront(val)
+}
+
+// PopFront - removes and returns the first value, false for empty deque
+func (d *Deque[T]) PopFront() (val T, ok bool) {
+	if d.items.length == 0 {
+		return val, false
+	}
+
+	return d.items.pop(), true
+}
+
+// PopBack - removes and returns the last value, false for empty deque
+func (d *Deque[T]) PopBack() (val T, ok bool) {
+	if d.items.length == 0 {
+		return val, false
+	}
+
+	return d.items.popBack(), true
+}
+
+// Front - returns the first value without removing, false for empty deque
+func (d *Deque[T]) Front() (val T, ok bool) {
+	return d.Get(0)
+}
+
+// Back - returns the last value without removing, false for empty deque
+func (d *Deque[T]) Back() (val T, ok bool) {
+	return d.Get(d.items.length - 1)
+}
+
+// Get - returns value by index from the front, false for index out of range
+func (d *Deque[T]) Get(index int) (val T, ok bool) {
+	if index < 0 || index >= d.items.length {
+		return val, false
+	}
+
+	return d.items.at(index), true
+}
+
+func (d *Deque[T]) Len() int {
+	return d.items.length
+}
+
+func (d *Deque[T]) Clear() {
+	clear(d.items.buf)
+	d.items.head, d.items.length = 0, 0
+}
+
+// Values - returns copy of values from the front to the back
+func (d *Deque[T]) Values() []T {
+	return d.items.values()
+}
+
+// Seq - iterates over values from the front to the back, the deque must not be modified while iterating
+func (d *Deque[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range d.items.length {
+			if !yield(d.items.at(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Drain - pops values from the front until the deque is empty
+func (d *Deque[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			val, ok := d.PopFront()
+			if !ok || !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// SyncDeque - concurrency-safe Deque. It must not be copied after first use.
+type SyncDeque[T any] struct {
+	mx    sync.Mutex
+	deque Deque[T]
+}
+
+func NewSyncDeque[T any](capacity int) *SyncDeque[T] {
+	return &SyncDeque[T]{deque: Deque[T]{items: makeRing[T](capacity)}}
+}
+
+func (d *SyncDeque[T]) PushBack(val T) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.deque.PushBack(val)
+}
+
+func (d *SyncDeque[T]) PushFront(val T) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.deque.PushFront(val)
+}
+
+// PopFront - removes and returns the first value, false for empty deque
+func (d *SyncDeque[T]) PopFront() (val T, ok bool) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return d.deque.PopFront()
+}
+
+// PopBack - removes and returns the last value, false for empty deque
+func (d *SyncDeque[T]) PopBack() (val T, ok bool) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return d.deque.PopBack()
+}
+
+// Front - returns the first value without removing, false for empty deque
+func (d *SyncDeque[T]) Front() (val T, ok bool) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return d.deque.Front()
+}
+
+// Back - returns the last value without removing, false for empty deque
+func (d *SyncDeque[T]) Back() (val T, ok bool) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return d.deque.Back()
+}
+
+// Get - returns value by index from the front, false for index out of range
+func (d *SyncDeque[T]) Get(index int) (val T, ok bool) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return d.deque.Get(index)
+}
+
+func (d *SyncDeque[T]) Len() int {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return d.deque.Len()
+}
+
+func (d *SyncDeque[T]) Clear() {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.deque.Clear()
+}
+
+// Values - returns copy of values from the front to the back
+func (d *SyncDeque[T]) Values() []T {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return d.deque.Values()
+}
+
+// Seq - iterates over copy of values from the front to the back
+func (d *SyncDeque[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range d.Values() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Drain - pops values from the front until the deque is empty, the lock is held only while popping
+func (d *SyncDeque[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			val, ok := d.PopFront()
+			if !ok || !yield(val) {
+				return
+			}
+		}
+	}
+}
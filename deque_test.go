@@ -0,0 +1,100 @@
+package dot_test
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mirrorru/dot"
+)
+
+func TestDeque(t *testing.T) {
+	t.Parallel()
+
+	var d dot.Deque[int]
+	_, ok := d.PopFront()
+	assert.False(t, ok)
+	_, ok = d.PopBack()
+	assert.False(t, ok)
+	_, ok = d.Back()
+	assert.False(t, ok)
+
+	// grows through wrapped state
+	for i := range 20 {
+		if i%2 == 0 {
+			d.PushBack(i)
+		} else {
+			d.PushFront(i)
+		}
+	}
+	assert.Equal(t, 20, d.Len())
+	assert.Equal(t, []int{19, 17, 15, 13, 11, 9, 7, 5, 3, 1, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18}, d.Values())
+	assert.Equal(t, d.Values(), slices.Collect(d.Seq()))
+
+	val, ok := d.Front()
+	assert.True(t, ok)
+	assert.Equal(t, 19, val)
+	val, ok = d.Back()
+	assert.True(t, ok)
+	assert.Equal(t, 18, val)
+	val, ok = d.Get(10)
+	assert.True(t, ok)
+	assert.Equal(t, 0, val)
+	_, ok = d.Get(20)
+	assert.False(t, ok)
+
+	val, _ = d.PopFront()
+	assert.Equal(t, 19, val)
+	val, _ = d.PopBack()
+	assert.Equal(t, 18, val)
+	assert.Equal(t, 18, d.Len())
+
+	for v := range d.Drain() {
+		if v == 1 {
+			break
+		}
+	}
+	assert.Equal(t, []int{0, 2, 4, 6, 8, 10, 12, 14, 16}, d.Values())
+
+	d.Clear()
+	assert.Equal(t, 0, d.Len())
+	d.PushFront(1)
+	assert.Equal(t, []int{1}, d.Values())
+}
+
+func TestSyncDeque(t *testing.T) {
+	t.Parallel()
+
+	d := dot.NewSyncDeque[int](2)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				d.PushBack(i)
+				d.PushFront(i)
+				d.PopBack()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1000, d.Len())
+
+	front, _ := d.Front()
+	back, _ := d.Back()
+	val, ok := d.Get(0)
+	assert.True(t, ok)
+	assert.Equal(t, front, val)
+	val, _ = d.Get(999)
+	assert.Equal(t, back, val)
+	assert.Equal(t, d.Values(), slices.Collect(d.Seq()))
+
+	d.PopFront()
+	assert.Len(t, slices.Collect(d.Drain()), 999)
+	d.PushFront(1)
+	d.Clear()
+	assert.Equal(t, 0, d.Len())
+}
@@ -0,0 +1,311 @@
+// Package iters provides lazy combinators over iter.Seq and iter.Seq2.
+package iters
+
+import (
+	"iter"
+
+	"github.com/mirrorru/dot"
+)
+
+// Map - converts every value by converter
+func Map[FROM, TO any](seq iter.Seq[FROM], converter func(FROM) TO) iter.Seq[TO] {
+	return func(yield func(TO) bool) {
+		for v := range seq {
+			if !yield(converter(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Map2 - converts every pair by converter
+func Map2[K1, V1, K2, V2 any](seq iter.Seq2[K1, V1], converter func(K1, V1) (K2, V2)) iter.Seq2[K2, V2] {
+	return func(yield func(K2, V2) bool) {
+		for k, v := range seq {
+			if !yield(converter(k, v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter - yields values matched by keep
+func Filter[T any](seq iter.Seq[T], keep func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if keep(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Filter2 - yields pairs matched by keep
+func Filter2[K, V any](seq iter.Seq2[K, V], keep func(K, V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq {
+			if keep(k, v) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Keys - yields the first values of pairs
+func Keys[K, V any](seq iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range seq {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values - yields the second values of pairs
+func Values[K, V any](seq iter.Seq2[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range seq {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Take - yields up to n first values
+func Take[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		count := 0
+		for v := range seq {
+			count++
+			if !yield(v) || count >= n {
+				return
+			}
+		}
+	}
+}
+
+// Take2 - yields up to n first pairs
+func Take2[K, V any](seq iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if n <= 0 {
+			return
+		}
+		count := 0
+		for k, v := range seq {
+			count++
+			if !yield(k, v) || count >= n {
+				return
+			}
+		}
+	}
+}
+
+// Skip - yields values after n first ones
+func Skip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		count := 0
+		for v := range seq {
+			count++
+			if count > n && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Skip2 - yields pairs after n first ones
+func Skip2[K, V any](seq iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		count := 0
+		for k, v := range seq {
+			count++
+			if count > n && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhile - yields values while predicate is true
+func TakeWhile[T any](seq iter.Seq[T], predicate func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if !predicate(v) || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SkipWhile - skips values while predicate is true, then yields the rest
+func SkipWhile[T any](seq iter.Seq[T], predicate func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		skipping := true
+		for v := range seq {
+			skipping = skipping && predicate(v)
+			if !skipping && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Chain - yields values of all sequences one after another
+func Chain[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, seq := range seqs {
+			for v := range seq {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Zip - yields pairs of values with the same position, stops at the end of the shorter sequence
+func Zip[A, B any](first iter.Seq[A], second iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		nextB, stop := iter.Pull(second)
+		defer stop()
+
+		for a := range first {
+			b, ok := nextB()
+			if !ok || !yield(a, b) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerate - yields values with their positions starting from zero
+func Enumerate[T any](seq iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range seq {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Batch - yields consecutive values grouped by size, the last batch may be shorter. Panics if size < 1.
+// Every batch is a new slice.
+func Batch[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	if size < 1 {
+		panic("iters.Batch: size must be positive")
+	}
+
+	return func(yield func([]T) bool) {
+		batch := make([]T, 0, size)
+		for v := range seq {
+			batch = append(batch, v)
+			if len(batch) == size {
+				if !yield(batch) {
+					return
+				}
+				batch = make([]T, 0, size)
+			}
+		}
+		if len(batch) > 0 {
+			yield(batch)
+		}
+	}
+}
+
+// Distinct - yields the first occurrence of every value
+func Distinct[T comparable](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := dot.NewSet[T]()
+		for v := range seq {
+			if seen.Contains(v) {
+				continue
+			}
+			seen.Add(v)
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Collect - collects values into new slice, nil for empty sequence
+func Collect[T any](seq iter.Seq[T]) []T {
+	var result []T
+	for v := range seq {
+		result = append(result, v)
+	}
+
+	return result
+}
+
+// CollectMap - collects pairs into new map, the last value wins for duplicate keys
+func CollectMap[K comparable, V any](seq iter.Seq2[K, V]) map[K]V {
+	result := make(map[K]V)
+	for k, v := range seq {
+		result[k] = v
+	}
+
+	return result
+}
+
+// Fold - accumulates values into initial by folder
+func Fold[T, ACC any](seq iter.Seq[T], initial ACC, folder func(acc ACC, val T) ACC) ACC {
+	acc := initial
+	for v := range seq {
+		acc = folder(acc, v)
+	}
+
+	return acc
+}
+
+// Reduce - accumulates values into the first one by reducer, false for empty sequence
+func Reduce[T any](seq iter.Seq[T], reducer func(acc T, val T) T) (result T, ok bool) {
+	for v := range seq {
+		if !ok {
+			result, ok = v, true
+			continue
+		}
+		result = reducer(result, v)
+	}
+
+	return result, ok
+}
+
+// First - returns the first value, false for empty sequence
+func First[T any](seq iter.Seq[T]) (val T, ok bool) {
+	for v := range seq {
+		return v, true
+	}
+
+	return val, false
+}
+
+// Any - reports whether any value is matched by predicate, stops on the first match
+func Any[T any](seq iter.Seq[T], predicate func(T) bool) bool {
+	for v := range seq {
+		if predicate(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// All - reports whether all values are matched by predicate, stops on the first mismatch
+func All[T any](seq iter.Seq[T], predicate func(T) bool) bool {
+	for v := range seq {
+		if !predicate(v) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,134 @@
+package iters_test
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mirrorru/dot"
+	"github.com/mirrorru/dot/iters"
+)
+
+// countingSeq yields 0..n-1 and counts pulled values
+func countingSeq(n int, pulled *int) func(yield func(int) bool) {
+	return func(yield func(int) bool) {
+		for i := range n {
+			*pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestMapFilter(t *testing.T) {
+	t.Parallel()
+
+	seq := iters.Map(iters.Filter(slices.Values([]int{1, 2, 3, 4}), func(v int) bool {
+		return v%2 == 0
+	}), strconv.Itoa)
+	assert.Equal(t, []string{"2", "4"}, iters.Collect(seq))
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	pairs := iters.Map2(iters.Filter2(maps.All(m), func(_ string, v int) bool {
+		return v > 1
+	}), func(k string, v int) (int, string) {
+		return v, k
+	})
+	assert.Equal(t, map[int]string{2: "b", 3: "c"}, iters.CollectMap(pairs))
+
+	assert.Equal(t, []string{"a", "b", "c"}, slices.Sorted(iters.Keys(maps.All(m))))
+	assert.Equal(t, []int{1, 2, 3}, slices.Sorted(iters.Values(maps.All(m))))
+}
+
+func TestTakeSkip(t *testing.T) {
+	t.Parallel()
+
+	pulled := 0
+	assert.Equal(t, []int{0, 1, 2}, iters.Collect(iters.Take(countingSeq(100, &pulled), 3)))
+	assert.Equal(t, 3, pulled, "Take must not pull extra values")
+	assert.Nil(t, iters.Collect(iters.Take(countingSeq(100, &pulled), 0)))
+
+	assert.Equal(t, []int{7, 8, 9}, iters.Collect(iters.Skip(countingSeq(10, &pulled), 7)))
+	assert.Nil(t, iters.Collect(iters.Skip(countingSeq(3, &pulled), 5)))
+
+	seq2 := slices.All([]string{"a", "b", "c", "d"})
+	assert.Equal(t, map[int]string{1: "b", 2: "c"}, iters.CollectMap(iters.Take2(iters.Skip2(seq2, 1), 2)))
+
+	less := func(v int) bool { return v < 3 }
+	assert.Equal(t, []int{0, 1, 2}, iters.Collect(iters.TakeWhile(countingSeq(10, &pulled), less)))
+	assert.Equal(t, []int{3, 4, 0}, iters.Collect(iters.SkipWhile(slices.Values([]int{0, 1, 3, 4, 0}), less)))
+}
+
+func TestChainZipEnumerate(t *testing.T) {
+	t.Parallel()
+
+	chained := iters.Chain(slices.Values([]int{1, 2}), slices.Values([]int(nil)), slices.Values([]int{3}))
+	assert.Equal(t, []int{1, 2, 3}, iters.Collect(chained))
+	assert.Equal(t, []int{1, 2}, iters.Collect(iters.Take(chained, 2)))
+
+	zipped := iters.Zip(slices.Values([]int{1, 2, 3}), slices.Values([]string{"a", "b"}))
+	assert.Equal(t, map[int]string{1: "a", 2: "b"}, iters.CollectMap(zipped))
+
+	enumerated := iters.Enumerate(slices.Values([]string{"a", "b"}))
+	assert.Equal(t, map[int]string{0: "a", 1: "b"}, iters.CollectMap(enumerated))
+}
+
+func TestBatchDistinct(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, [][]int{{0, 1}, {2, 3}, {4}}, iters.Collect(iters.Batch(slices.Values([]int{0, 1, 2, 3, 4}), 2)))
+	assert.Nil(t, iters.Collect(iters.Batch(slices.Values([]int{}), 2)))
+	assert.Panics(t, func() { iters.Batch(slices.Values([]int{}), 0) })
+
+	assert.Equal(t, []int{1, 2, 3}, iters.Collect(iters.Distinct(slices.Values([]int{1, 2, 1, 3, 2}))))
+}
+
+func TestFoldReduceFirstAnyAll(t *testing.T) {
+	t.Parallel()
+
+	sum := func(acc, v int) int { return acc + v }
+	pulled := 0
+	assert.Equal(t, 45, iters.Fold(countingSeq(10, &pulled), 0, sum))
+	assert.Equal(t, "x123", iters.Fold(slices.Values([]int{1, 2, 3}), "x", func(acc string, v int) string {
+		return acc + strconv.Itoa(v)
+	}))
+
+	total, ok := iters.Reduce(slices.Values([]int{4, 5, 6}), sum)
+	assert.True(t, ok)
+	assert.Equal(t, 15, total)
+	total, ok = iters.Reduce(slices.Values([]int{7}), sum)
+	assert.True(t, ok)
+	assert.Equal(t, 7, total)
+	_, ok = iters.Reduce(slices.Values([]int{}), sum)
+	assert.False(t, ok)
+
+	first, ok := iters.First(slices.Values([]int{5, 6}))
+	assert.True(t, ok)
+	assert.Equal(t, 5, first)
+	_, ok = iters.First(slices.Values([]int{}))
+	assert.False(t, ok)
+
+	pulled = 0
+	assert.True(t, iters.Any(countingSeq(100, &pulled), func(v int) bool { return v == 2 }))
+	assert.Equal(t, 3, pulled, "Any must stop on the first match")
+	assert.False(t, iters.Any(slices.Values([]int{1}), func(v int) bool { return v == 2 }))
+
+	pulled = 0
+	assert.False(t, iters.All(countingSeq(100, &pulled), func(v int) bool { return v < 2 }))
+	assert.Equal(t, 3, pulled, "All must stop on the first mismatch")
+	assert.True(t, iters.All(slices.Values([]int{}), func(int) bool { return false }))
+}
+
+func TestSyncSliceComposition(t *testing.T) {
+	t.Parallel()
+
+	s := dot.NewSyncSlice[int](0, 5)
+	s.AppendAll(1, 2, 3, 4, 5)
+
+	squares := iters.Map(iters.Filter(s.Seq(), func(v int) bool { return v%2 == 1 }), func(v int) int { return v * v })
+	assert.Equal(t, []int{1, 9, 25}, iters.Collect(squares))
+}
@@ -0,0 +1,81 @@
+package iters
+
+import (
+	"iter"
+
+	"github.com/mirrorru/dot"
+)
+
+// MapErr - converts every value by converter, yielding results.
+// Stops after yielding the first error.
+func MapErr[FROM, TO any](seq iter.Seq[FROM], converter func(FROM) (TO, error)) iter.Seq[dot.Result[TO]] {
+	return func(yield func(dot.Result[TO]) bool) {
+		for v := range seq {
+			res := dot.MakeResult(converter(v))
+			if !yield(res) || res.IsErr() {
+				return
+			}
+		}
+	}
+}
+
+// MapResults - converts values of successful results by converter.
+// Stops after yielding the first error, either received or returned by converter.
+func MapResults[FROM, TO any](
+	seq iter.Seq[dot.Result[FROM]], converter func(FROM) (TO, error),
+) iter.Seq[dot.Result[TO]] {
+	return func(yield func(dot.Result[TO]) bool) {
+		for src := range seq {
+			res := dot.TransformResult(src, converter)
+			if !yield(res) || res.IsErr() {
+				return
+			}
+		}
+	}
+}
+
+// FilterResults - yields successful results matched by keep and the first error, then stops
+func FilterResults[T any](seq iter.Seq[dot.Result[T]], keep func(T) bool) iter.Seq[dot.Result[T]] {
+	return func(yield func(dot.Result[T]) bool) {
+		for res := range seq {
+			if res.IsErr() {
+				yield(res)
+				return
+			}
+			if keep(res.Val()) && !yield(res) {
+				return
+			}
+		}
+	}
+}
+
+// CollectErr - collects values of results into new slice, stops on the first error and returns it
+func CollectErr[T any](seq iter.Seq[dot.Result[T]]) ([]T, error) {
+	var result []T
+	for res := range seq {
+		val, err := res.Unwarp()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, val)
+	}
+
+	return result, nil
+}
+
+// FoldErr - accumulates values of results into initial by folder, stops on the first error and returns it
+func FoldErr[T, ACC any](
+	seq iter.Seq[dot.Result[T]], initial ACC, folder func(acc ACC, val T) (ACC, error),
+) (acc ACC, err error) {
+	acc = initial
+	for res := range seq {
+		if res.IsErr() {
+			return acc, res.Err()
+		}
+		if acc, err = folder(acc, res.Val()); err != nil {
+			return acc, err
+		}
+	}
+
+	return acc, nil
+}
@@ -0,0 +1,77 @@
+package iters_test
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirrorru/dot"
+	"github.com/mirrorru/dot/iters"
+)
+
+func TestMapErr(t *testing.T) {
+	t.Parallel()
+
+	results := iters.Collect(iters.MapErr(slices.Values([]string{"1", "x", "3"}), strconv.Atoi))
+	require.Len(t, results, 2, "iteration must stop after the first error")
+	assert.Equal(t, 1, results[0].Val())
+	require.Error(t, results[1].Err())
+
+	values, err := iters.CollectErr(iters.MapErr(slices.Values([]string{"1", "2"}), strconv.Atoi))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, values)
+
+	_, err = iters.CollectErr(iters.MapErr(slices.Values([]string{"1", "x"}), strconv.Atoi))
+	require.Error(t, err)
+}
+
+func TestMapFilterResults(t *testing.T) {
+	t.Parallel()
+
+	errBroken := errors.New("broken")
+	src := slices.Values([]dot.Result[int]{
+		dot.MakeResult(1, nil),
+		dot.MakeResult(2, nil),
+		dot.MakeResult(3, nil),
+		dot.MakeResult(0, errBroken),
+		dot.MakeResult(4, nil),
+	})
+
+	strs := iters.MapResults(src, func(v int) (string, error) {
+		return strconv.Itoa(v * 10), nil
+	})
+	values, err := iters.CollectErr(iters.Take(strs, 3))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10", "20", "30"}, values)
+
+	_, err = iters.CollectErr(strs)
+	require.ErrorIs(t, err, errBroken)
+
+	odd := iters.Collect(iters.FilterResults(src, func(v int) bool { return v%2 == 1 }))
+	require.Len(t, odd, 3)
+	assert.Equal(t, 1, odd[0].Val())
+	assert.Equal(t, 3, odd[1].Val())
+	require.ErrorIs(t, odd[2].Err(), errBroken)
+}
+
+func TestFoldErr(t *testing.T) {
+	t.Parallel()
+
+	sum := func(acc, v int) (int, error) { return acc + v, nil }
+
+	total, err := iters.FoldErr(iters.MapErr(slices.Values([]string{"1", "2", "3"}), strconv.Atoi), 0, sum)
+	require.NoError(t, err)
+	assert.Equal(t, 6, total)
+
+	total, err = iters.FoldErr(iters.MapErr(slices.Values([]string{"1", "x", "3"}), strconv.Atoi), 0, sum)
+	require.Error(t, err)
+	assert.Equal(t, 1, total)
+
+	_, err = iters.FoldErr(iters.MapErr(slices.Values([]string{"1"}), strconv.Atoi), 0,
+		func(int, int) (int, error) { return 0, assert.AnError })
+	require.ErrorIs(t, err, assert.AnError)
+}
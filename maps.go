@@ -0,0 +1,210 @@
+package dot
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ErrKeyCollision - several source entries produce the same key
+var ErrKeyCollision = errors.New("key collision")
+
+// MapKeys - returns keys of map in unspecified order
+func MapKeys[K comparable, V any](source map[K]V) []K {
+	if source == nil {
+		return nil
+	}
+
+	return slices.AppendSeq(make([]K, 0, len(source)), maps.Keys(source))
+}
+
+// SortedMapKeys - returns keys of map in ascending order
+func SortedMapKeys[K cmp.Ordered, V any](source map[K]V) []K {
+	result := MapKeys(source)
+	slices.Sort(result)
+
+	return result
+}
+
+// MapValues - returns values of map in unspecified order
+func MapValues[K comparable, V any](source map[K]V) []V {
+	if source == nil {
+		return nil
+	}
+
+	return slices.AppendSeq(make([]V, 0, len(source)), maps.Values(source))
+}
+
+// SortedMapValues - returns values of map in ascending order
+func SortedMapValues[K comparable, V cmp.Ordered](source map[K]V) []V {
+	result := MapValues(source)
+	slices.Sort(result)
+
+	return result
+}
+
+// MapToMap - converts keys and values of map to new types.
+// If converted keys collide, any of their values wins.
+func MapToMap[K1, K2 comparable, V1, V2 any](
+	source map[K1]V1, keyConverter func(K1) K2, valueConverter func(V1) V2,
+) map[K2]V2 {
+	if source == nil {
+		return nil
+	}
+
+	result := make(map[K2]V2, len(source))
+	for k, v := range source {
+		result[keyConverter(k)] = valueConverter(v)
+	}
+
+	return result
+}
+
+// MapToMapError - converts keys and values of map to new types, stops on the first converter error.
+// Returns ErrKeyCollision if converted keys collide.
+func MapToMapError[K1, K2 comparable, V1, V2 any](
+	source map[K1]V1, keyConverter func(K1) (K2, error), valueConverter func(V1) (V2, error),
+) (map[K2]V2, error) {
+	if source == nil {
+		return nil, nil
+	}
+
+	result := make(map[K2]V2, len(source))
+	for k, v := range source {
+		key, err := keyConverter(k)
+		if err != nil {
+			return nil, err
+		}
+		if _, found := result[key]; found {
+			return nil, fmt.Errorf("%w: %v", ErrKeyCollision, key)
+		}
+		if result[key], err = valueConverter(v); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// MapToSlice - converts entries of map to slice in unspecified order
+func MapToSlice[K comparable, V, T any](source map[K]V, converter func(K, V) T) []T {
+	if source == nil {
+		return nil
+	}
+
+	result := make([]T, 0, len(source))
+	for k, v := range source {
+		result = append(result, converter(k, v))
+	}
+
+	return result
+}
+
+// SliceToMap - converts values of slice to map entries, the last value wins for duplicate keys
+func SliceToMap[T any, K comparable, V any](source []T, converter func(T) (K, V)) map[K]V {
+	if source == nil {
+		return nil
+	}
+
+	result := make(map[K]V, len(source))
+	for i := range source {
+		k, v := converter(source[i])
+		result[k] = v
+	}
+
+	return result
+}
+
+// InvertMap - swaps keys and values of map. Returns ErrKeyCollision if values are not unique.
+func InvertMap[K, V comparable](source map[K]V) (map[V]K, error) {
+	if source == nil {
+		return nil, nil
+	}
+
+	result := make(map[V]K, len(source))
+	for k, v := range source {
+		if _, found := result[v]; found {
+			return nil, fmt.Errorf("%w: %v", ErrKeyCollision, v)
+		}
+		result[v] = k
+	}
+
+	return result, nil
+}
+
+// MergeMaps - merges maps into new one. For keys present in several maps
+// resolve receives the merged value and the value of the later map, nil resolve means the later value wins.
+func MergeMaps[K comparable, V any](resolve func(key K, merged, next V) V, sources ...map[K]V) map[K]V {
+	size := 0
+	for _, source := range sources {
+		size = max(size, len(source))
+	}
+
+	result := make(map[K]V, size)
+	for _, source := range sources {
+		for k, v := range source {
+			if merged, found := result[k]; found && resolve != nil {
+				v = resolve(k, merged, v)
+			}
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+// MapFilter - returns new map of entries matched by keep
+func MapFilter[K comparable, V any](source map[K]V, keep func(K, V) bool) map[K]V {
+	if source == nil {
+		return nil
+	}
+
+	result := make(map[K]V)
+	for k, v := range source {
+		if keep(k, v) {
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+// MapDiff - keys difference of two maps
+type MapDiff[K comparable] struct {
+	Added   Set[K] // keys present only in the new map
+	Removed Set[K] // keys present only in the old map
+	Changed Set[K] // keys present in both maps with different values
+}
+
+// IsEmpty - reports whether maps are equal
+func (d MapDiff[K]) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffMaps - compares keys and values of old and new maps
+func DiffMaps[K, V comparable](oldMap, newMap map[K]V) MapDiff[K] {
+	return DiffMapsFunc(oldMap, newMap, func(a, b V) bool { return a == b })
+}
+
+// DiffMapsFunc - compares keys of old and new maps and their values by eq
+func DiffMapsFunc[K comparable, V any](oldMap, newMap map[K]V, eq func(a, b V) bool) MapDiff[K] {
+	diff := MapDiff[K]{Added: NewSet[K](), Removed: NewSet[K](), Changed: NewSet[K]()}
+	for k, oldVal := range oldMap {
+		newVal, found := newMap[k]
+		switch {
+		case !found:
+			diff.Removed.Add(k)
+		case !eq(oldVal, newVal):
+			diff.Changed.Add(k)
+		}
+	}
+	for k := range newMap {
+		if _, found := oldMap[k]; !found {
+			diff.Added.Add(k)
+		}
+	}
+
+	return diff
+}
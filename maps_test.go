@@ -0,0 +1,128 @@
+package dot_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirrorru/dot"
+)
+
+func TestMapKeysValues(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]int{"b": 1, "c": 3, "a": 2}
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, dot.MapKeys(m))
+	assert.ElementsMatch(t, []int{1, 2, 3}, dot.MapValues(m))
+	assert.Equal(t, []string{"a", "b", "c"}, dot.SortedMapKeys(m))
+	assert.Equal(t, []int{1, 2, 3}, dot.SortedMapValues(m))
+
+	assert.Nil(t, dot.MapKeys(map[string]int(nil)))
+	assert.Nil(t, dot.SortedMapValues(map[string]int(nil)))
+	assert.Equal(t, []string{}, dot.SortedMapKeys(map[string]int{}))
+}
+
+func TestMapToMap(t *testing.T) {
+	t.Parallel()
+
+	m := map[int]int{1: 10, 2: 20}
+	assert.Equal(t, map[string]string{"1": "10", "2": "20"}, dot.MapToMap(m, strconv.Itoa, strconv.Itoa))
+	assert.Nil(t, dot.MapToMap(map[int]int(nil), strconv.Itoa, strconv.Itoa))
+
+	atoi := func(s string) (int, error) { return strconv.Atoi(s) }
+	res, err := dot.MapToMapError(map[string]string{"1": "10", "2": "20"}, atoi, atoi)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int{1: 10, 2: 20}, res)
+
+	_, err = dot.MapToMapError(map[string]string{"x": "10"}, atoi, atoi)
+	require.Error(t, err)
+	_, err = dot.MapToMapError(map[string]string{"1": "x"}, atoi, atoi)
+	require.Error(t, err)
+	_, err = dot.MapToMapError(map[string]string{"1": "10", "01": "20"}, atoi, atoi)
+	require.ErrorIs(t, err, dot.ErrKeyCollision)
+
+	res, err = dot.MapToMapError(map[string]string(nil), atoi, atoi)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestMapToSlice_SliceToMap(t *testing.T) {
+	t.Parallel()
+
+	pairs := dot.MapToSlice(map[string]int{"a": 1, "b": 2}, func(k string, v int) dot.Pair[string, int] {
+		return dot.Pair[string, int]{First: k, Second: v}
+	})
+	assert.ElementsMatch(t, []dot.Pair[string, int]{{First: "a", Second: 1}, {First: "b", Second: 2}}, pairs)
+	assert.Nil(t, dot.MapToSlice(map[string]int(nil), func(string, int) int { return 0 }))
+
+	m := dot.SliceToMap([]string{"a=1", "b=2", "a=3"}, func(s string) (string, string) {
+		k, v, _ := strings.Cut(s, "=")
+		return k, v
+	})
+	assert.Equal(t, map[string]string{"a": "3", "b": "2"}, m)
+	assert.Nil(t, dot.SliceToMap([]string(nil), func(s string) (string, int) { return s, 0 }))
+}
+
+func TestInvertMap(t *testing.T) {
+	t.Parallel()
+
+	res, err := dot.InvertMap(map[string]int{"a": 1, "b": 2})
+	require.NoError(t, err)
+	assert.Equal(t, map[int]string{1: "a", 2: "b"}, res)
+
+	_, err = dot.InvertMap(map[string]int{"a": 1, "b": 1})
+	require.ErrorIs(t, err, dot.ErrKeyCollision)
+	assert.Equal(t, "key collision: 1", err.Error())
+
+	res, err = dot.InvertMap(map[string]int(nil))
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestMergeMaps(t *testing.T) {
+	t.Parallel()
+
+	a := map[string]int{"a": 1, "b": 2}
+	b := map[string]int{"b": 3, "c": 4}
+	c := map[string]int{"b": 5}
+
+	assert.Equal(t, map[string]int{"a": 1, "b": 5, "c": 4}, dot.MergeMaps(nil, a, b, c))
+	sum := func(_ string, merged, next int) int { return merged + next }
+	assert.Equal(t, map[string]int{"a": 1, "b": 10, "c": 4}, dot.MergeMaps(sum, a, nil, b, c))
+	assert.Equal(t, map[string]int{}, dot.MergeMaps[string, int](nil))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, a)
+}
+
+func TestMapFilter(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	even := func(_ string, v int) bool { return v%2 == 0 }
+	assert.Equal(t, map[string]int{"b": 2}, dot.MapFilter(m, even))
+	assert.Nil(t, dot.MapFilter(map[string]int(nil), even))
+}
+
+func TestDiffMaps(t *testing.T) {
+	t.Parallel()
+
+	oldMap := map[string]int{"a": 1, "b": 2, "c": 3}
+	newMap := map[string]int{"b": 2, "c": 30, "d": 4}
+
+	diff := dot.DiffMaps(oldMap, newMap)
+	assert.Equal(t, dot.Set[string]{"d": {}}, diff.Added)
+	assert.Equal(t, dot.Set[string]{"a": {}}, diff.Removed)
+	assert.Equal(t, dot.Set[string]{"c": {}}, diff.Changed)
+	assert.False(t, diff.IsEmpty())
+	assert.True(t, dot.DiffMaps(oldMap, oldMap).IsEmpty())
+	assert.True(t, dot.DiffMaps[string, int](nil, nil).IsEmpty())
+
+	errA, errB := errors.New("a"), errors.New("a")
+	errDiff := dot.DiffMapsFunc(map[int]error{1: errA}, map[int]error{1: errB}, func(a, b error) bool {
+		return a.Error() == b.Error()
+	})
+	assert.True(t, errDiff.IsEmpty())
+}
@@ -0,0 +1,125 @@
+package dot
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"slices"
+)
+
+// MultiSet - counter of values occurrences
+type MultiSet[T comparable] map[T]int
+
+// MultiSetItem - value with its count
+type MultiSetItem[T comparable] struct {
+	Val   T
+	Count int
+}
+
+func NewMultiSet[T comparable]() MultiSet[T] {
+	return make(MultiSet[T])
+}
+
+// MultiSetFromSet - makes MultiSet with count 1 for each value of set
+func MultiSetFromSet[T comparable](set Set[T]) MultiSet[T] {
+	ms := make(MultiSet[T], len(set))
+	for value := range set {
+		ms[value] = 1
+	}
+
+	return ms
+}
+
+// Add - changes count of value by n. Values with non-positive count are removed.
+// Count saturates at math.MaxInt instead of overflowing.
+func (ms MultiSet[T]) Add(value T, n int) {
+	count := saturatedAdd(ms[value], n)
+	if count <= 0 {
+		delete(ms, value)
+		return
+	}
+	ms[value] = count
+}
+
+// Count - returns count of value, zero for absent value
+func (ms MultiSet[T]) Count(value T) int {
+	return ms[value]
+}
+
+// Total - returns sum of all counts, saturated at math.MaxInt
+func (ms MultiSet[T]) Total() (total int) {
+	for _, count := range ms {
+		total = saturatedAdd(total, count)
+	}
+
+	return total
+}
+
+// saturatedAdd returns count+n, limited by math.MaxInt. count must not be negative.
+func saturatedAdd(count, n int) int {
+	if n > 0 && count > math.MaxInt-n {
+		return math.MaxInt
+	}
+
+	return count + n
+}
+
+// Merge - adds counts of another MultiSet
+func (ms MultiSet[T]) Merge(another MultiSet[T]) {
+	for value, count := range another {
+		ms.Add(value, count)
+	}
+}
+
+// Subtract - subtracts counts of another MultiSet. Values with non-positive count are removed.
+func (ms MultiSet[T]) Subtract(another MultiSet[T]) {
+	for value, count := range another {
+		ms.Add(value, -count)
+	}
+}
+
+// ToSet - returns Set of values with positive count
+func (ms MultiSet[T]) ToSet() Set[T] {
+	set := make(Set[T], len(ms))
+	for value := range ms {
+		set.Add(value)
+	}
+
+	return set
+}
+
+// MostCommon - returns up to k items with the biggest counts, in descending order of count.
+// Negative k means all items. Order of values with equal counts is not specified
+// and may differ between calls, so the k-th place may be taken by any of equal values.
+func (ms MultiSet[T]) MostCommon(k int) []MultiSetItem[T] {
+	items := ms.sortedItems()
+	if k >= 0 && k < len(items) {
+		items = items[:k]
+	}
+
+	return items
+}
+
+// Seq - iterates over values and counts in descending order of count.
+// Order of values with equal counts is not specified.
+func (ms MultiSet[T]) Seq() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for _, item := range ms.sortedItems() {
+			if !yield(item.Val, item.Count) {
+				return
+			}
+		}
+	}
+}
+
+func (ms MultiSet[T]) sortedItems() []MultiSetItem[T] {
+	items := make([]MultiSetItem[T], 0, len(ms))
+	for value, count := range ms {
+		items = append(items, MultiSetItem[T]{Val: value, Count: count})
+	}
+	slices.SortFunc(items, func(a, b MultiSetItem[T]) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+
+	return items
+}
@@ -0,0 +1,125 @@
+package dot_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mirrorru/dot"
+)
+
+func TestMultiSet_AddCount(t *testing.T) {
+	t.Parallel()
+
+	ms := dot.NewMultiSet[string]()
+	assert.Equal(t, 0, ms.Count("a"))
+
+	ms.Add("a", 2)
+	ms.Add("a", 1)
+	ms.Add("b", 1)
+	assert.Equal(t, 3, ms.Count("a"))
+	assert.Equal(t, 1, ms.Count("b"))
+	assert.Equal(t, 4, ms.Total())
+
+	ms.Add("b", -5)
+	assert.Equal(t, 0, ms.Count("b"))
+	assert.Len(t, ms, 1)
+}
+
+func TestMultiSet_MergeSubtract(t *testing.T) {
+	t.Parallel()
+
+	ms := dot.MultiSet[int]{1: 1, 2: 2}
+	ms.Merge(dot.MultiSet[int]{2: 3, 3: 1})
+	assert.Equal(t, dot.MultiSet[int]{1: 1, 2: 5, 3: 1}, ms)
+
+	ms.Subtract(dot.MultiSet[int]{1: 1, 2: 2, 4: 1})
+	assert.Equal(t, dot.MultiSet[int]{2: 3, 3: 1}, ms)
+}
+
+func TestMultiSet_Saturation(t *testing.T) {
+	t.Parallel()
+
+	ms := dot.NewMultiSet[string]()
+	ms.Add("a", math.MaxInt)
+	ms.Add("a", 1)
+	assert.Equal(t, math.MaxInt, ms.Count("a"))
+
+	another := dot.MultiSet[string]{"a": math.MaxInt, "b": math.MaxInt}
+	ms.Merge(another)
+	assert.Equal(t, math.MaxInt, ms.Count("a"))
+	assert.Equal(t, math.MaxInt, ms.Count("b"))
+	assert.Equal(t, math.MaxInt, ms.Total())
+
+	ms.Add("a", math.MinInt)
+	assert.Equal(t, 0, ms.Count("a"))
+	assert.Len(t, ms, 1)
+}
+
+func TestMultiSet_MostCommon(t *testing.T) {
+	t.Parallel()
+
+	ms := dot.MultiSet[string]{"a": 1, "b": 5, "c": 3}
+
+	tests := []struct {
+		name   string
+		k      int
+		expect []dot.MultiSetItem[string]
+	}{
+		{name: "zero", k: 0, expect: []dot.MultiSetItem[string]{}},
+		{name: "top two", k: 2, expect: []dot.MultiSetItem[string]{{Val: "b", Count: 5}, {Val: "c", Count: 3}}},
+		{name: "all", k: -1, expect: []dot.MultiSetItem[string]{
+			{Val: "b", Count: 5}, {Val: "c", Count: 3}, {Val: "a", Count: 1},
+		}},
+		{name: "too many", k: 10, expect: []dot.MultiSetItem[string]{
+			{Val: "b", Count: 5}, {Val: "c", Count: 3}, {Val: "a", Count: 1},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, ms.MostCommon(tt.k))
+		})
+	}
+
+	huge := dot.MultiSet[string]{"max": math.MaxInt, "min": 1}
+	huge["neg"] = math.MinInt + 1 // set directly, Add deletes non-positive counts
+	assert.Equal(t, []dot.MultiSetItem[string]{
+		{Val: "max", Count: math.MaxInt}, {Val: "min", Count: 1}, {Val: "neg", Count: math.MinInt + 1},
+	}, huge.MostCommon(-1))
+}
+
+func TestMultiSet_Seq(t *testing.T) {
+	t.Parallel()
+
+	ms := dot.MultiSet[string]{"a": 1, "b": 5, "c": 3}
+
+	var values []string
+	var counts []int
+	for value, count := range ms.Seq() {
+		values = append(values, value)
+		counts = append(counts, count)
+	}
+	assert.Equal(t, []string{"b", "c", "a"}, values)
+	assert.Equal(t, []int{5, 3, 1}, counts)
+}
+
+func TestMultiSet_Set(t *testing.T) {
+	t.Parallel()
+
+	set := dot.NewSet[int]()
+	set.Add(1)
+	set.Add(2)
+
+	ms := dot.MultiSetFromSet(set)
+	assert.Equal(t, dot.MultiSet[int]{1: 1, 2: 1}, ms)
+
+	ms.Add(3, 2)
+	back := ms.ToSet()
+	assert.True(t, back.Contains(1))
+	assert.True(t, back.Contains(2))
+	assert.True(t, back.Contains(3))
+	assert.Len(t, back, 3)
+}
@@ -0,0 +1,449 @@
+package dot
+
+import (
+	"cmp"
+	"context"
+	"iter"
+	"math/bits"
+	"math/rand/v2"
+	"strings"
+	"sync"
+)
+
+const orderedStoreMaxLevel = 32
+
+// OrderedSyncStore - concurrency-safe key-value store keeping keys in ascending order (skiplist based).
+// Methods follow SyncStore semantics, except that ForEach, Seq and Seq2 always work over a copy
+// made at the start of iteration (like SyncStore.ForEachSnapshot), so loop body may modify the store.
+type OrderedSyncStore[K cmp.Ordered, V any] struct {
+	mx     sync.RWMutex
+	head   skipNode[K, V]      // sentinel, its next has orderedStoreMaxLevel levels after init
+	level  int                 // count of used levels
+	length int                 // count of stored pairs
+	calls  map[K]*storeCall[V] // constructions in progress, see GetOrPutErr
+}
+
+type skipNode[K cmp.Ordered, V any] struct {
+	key  K
+	val  V
+	next []*skipNode[K, V]
+}
+
+func (s *OrderedSyncStore[K, V]) Put(key K, val V) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.set(key, val)
+}
+
+func (s *OrderedSyncStore[K, V]) GetCurrent(key K) (val V, founded bool) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	if node := s.find(key); node != nil {
+		return node.val, true
+	}
+
+	return val, false
+}
+
+// GetOrPut - see SyncStore.GetOrPut
+func (s *OrderedSyncStore[K, V]) GetOrPut(key K, maker func() V) V {
+	return s.GetOrPutErr(key, func() (V, error) {
+		return maker(), nil
+	}).Val()
+}
+
+// GetOrPutErr - see SyncStore.GetOrPutErr
+func (s *OrderedSyncStore[K, V]) GetOrPutErr(key K, maker func() (V, error)) Result[V] {
+	val, founded := s.GetCurrent(key)
+	if founded {
+		return MakeResult(val, nil)
+	}
+
+	s.mx.Lock()
+	if node := s.find(key); node != nil {
+		s.mx.Unlock()
+		return MakeResult(node.val, nil)
+	}
+
+	call, inProgress := s.calls[key]
+	if !inProgress {
+		call = &storeCall[V]{done: make(chan struct{})}
+		if s.calls == nil {
+			s.calls = make(map[K]*storeCall[V])
+		}
+		s.calls[key] = call
+	}
+	s.mx.Unlock()
+
+	if inProgress {
+		<-call.done
+	} else {
+		s.makeCall(key, call, maker)
+	}
+
+	if call.panicked {
+		panic(call.panicVal)
+	}
+
+	return MakeResult(call.val, call.err)
+}
+
+func (s *OrderedSyncStore[K, V]) makeCall(key K, call *storeCall[V], maker func() (V, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			call.panicked, call.panicVal = true, r
+		}
+
+		s.mx.Lock()
+		delete(s.calls, key)
+		if !call.panicked && call.err == nil {
+			if node := s.find(key); node != nil {
+				// value was put while maker worked
+				call.val = node.val
+			} else {
+				s.set(key, call.val)
+			}
+		}
+		s.mx.Unlock()
+
+		close(call.done)
+	}()
+
+	call.val, call.err = maker()
+}
+
+// Update - see SyncStore.Update
+func (s *OrderedSyncStore[K, V]) Update(key K, updater func(old V, found bool) (V, bool)) (val V, found bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if node := s.find(key); node != nil {
+		val, found = node.val, true
+	}
+	newVal, ok := updater(val, found)
+	if !ok {
+		return val, found
+	}
+	s.set(key, newVal)
+
+	return newVal, true
+}
+
+func (s *OrderedSyncStore[K, V]) Del(key K) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.remove(key)
+}
+
+// Swap - stores value and returns the previous one, if any
+func (s *OrderedSyncStore[K, V]) Swap(key K, val V) (previous V, loaded bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return s.set(key, val)
+}
+
+// LoadAndDelete - deletes key and returns its previous value, if any
+func (s *OrderedSyncStore[K, V]) LoadAndDelete(key K) (val V, loaded bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return s.remove(key)
+}
+
+// DeleteIf - see SyncStore.DeleteIf
+func (s *OrderedSyncStore[K, V]) DeleteIf(predicate func(key K, value V) bool) (deleted int) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	for node := s.first(); node != nil; {
+		next := node.next[0]
+		if predicate(node.key, node.val) {
+			s.remove(node.key)
+			deleted++
+		}
+		node = next
+	}
+
+	return deleted
+}
+
+// PutAll - stores all pairs of m under a single lock
+func (s *OrderedSyncStore[K, V]) PutAll(m map[K]V) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	for k, v := range m {
+		s.set(k, v)
+	}
+}
+
+// Clear - deletes all pairs
+func (s *OrderedSyncStore[K, V]) Clear() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	clear(s.head.next)
+	s.level = 0
+	s.length = 0
+}
+
+// Len - returns count of stored pairs
+func (s *OrderedSyncStore[K, V]) Len() int {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	return s.length
+}
+
+// Min - returns pair with the smallest key
+func (s *OrderedSyncStore[K, V]) Min() (key K, val V, founded bool) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	return nodePair(s.first())
+}
+
+// Max - returns pair with the biggest key
+func (s *OrderedSyncStore[K, V]) Max() (key K, val V, founded bool) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	x := &s.head
+	for lvl := s.level - 1; lvl >= 0; lvl-- {
+		for x.next[lvl] != nil {
+			x = x.next[lvl]
+		}
+	}
+	if x == &s.head {
+		return key, val, false
+	}
+
+	return nodePair(x)
+}
+
+// Floor - returns pair with the biggest key less than or equal to key
+func (s *OrderedSyncStore[K, V]) Floor(key K) (floorKey K, val V, founded bool) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	x := &s.head
+	for lvl := s.level - 1; lvl >= 0; lvl-- {
+		for x.next[lvl] != nil && x.next[lvl].key <= key {
+			x = x.next[lvl]
+		}
+	}
+	if x == &s.head {
+		return floorKey, val, false
+	}
+
+	return nodePair(x)
+}
+
+// Ceiling - returns pair with the smallest key greater than or equal to key
+func (s *OrderedSyncStore[K, V]) Ceiling(key K) (ceilingKey K, val V, founded bool) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	return nodePair(s.ceiling(key))
+}
+
+// ForEach - calls handler for every pair in ascending order of keys
+func (s *OrderedSyncStore[K, V]) ForEach(handler func(key K, value V)) {
+	for k, v := range s.Seq2() {
+		handler(k, v)
+	}
+}
+
+// ToMap - returns copy of stored pairs
+func (s *OrderedSyncStore[K, V]) ToMap() map[K]V {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	m := make(map[K]V, s.length)
+	for node := s.first(); node != nil; node = node.next[0] {
+		m[node.key] = node.val
+	}
+
+	return m
+}
+
+// IteratorCtx - see SyncStore.IteratorCtx, pairs are sent in ascending order of keys
+func (s *OrderedSyncStore[K, V]) IteratorCtx(ctx context.Context) <-chan struct {
+	Key   K
+	Value V
+} {
+	s.mx.RLock()
+	records := s.collect(s.first(), func(K) bool { return true })
+	s.mx.RUnlock()
+
+	return pairsChan(ctx, func(yield func(K, V) bool) {
+		yieldRecords(records, yield)
+	})
+}
+
+// Seq - iterates over values in ascending order of keys
+func (s *OrderedSyncStore[K, V]) Seq() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range s.Seq2() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2 - iterates over pairs in ascending order of keys
+func (s *OrderedSyncStore[K, V]) Seq2() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		s.mx.RLock()
+		records := s.collect(s.first(), func(K) bool { return true })
+		s.mx.RUnlock()
+
+		yieldRecords(records, yield)
+	}
+}
+
+// Range - iterates over pairs with keys in [from, to) in ascending order of keys
+func (s *OrderedSyncStore[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		s.mx.RLock()
+		records := s.collect(s.ceiling(from), func(key K) bool { return key < to })
+		s.mx.RUnlock()
+
+		yieldRecords(records, yield)
+	}
+}
+
+// OrderedStorePrefix - iterates over pairs with keys starting with prefix in ascending order of keys
+func OrderedStorePrefix[V any](s *OrderedSyncStore[string, V], prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		s.mx.RLock()
+		records := s.collect(s.ceiling(prefix), func(key string) bool {
+			return strings.HasPrefix(key, prefix)
+		})
+		s.mx.RUnlock()
+
+		yieldRecords(records, yield)
+	}
+}
+
+func nodePair[K cmp.Ordered, V any](node *skipNode[K, V]) (key K, val V, founded bool) {
+	if node == nil {
+		return key, val, false
+	}
+
+	return node.key, node.val, true
+}
+
+func yieldRecords[K comparable, V any](records []storeRecord[K, V], yield func(K, V) bool) {
+	for _, rec := range records {
+		if !yield(rec.Key, rec.Value) {
+			return
+		}
+	}
+}
+
+// collect copies pairs starting from node while cond is true, must be called under the lock
+func (s *OrderedSyncStore[K, V]) collect(node *skipNode[K, V], cond func(key K) bool) []storeRecord[K, V] {
+	var records []storeRecord[K, V]
+	for ; node != nil && cond(node.key); node = node.next[0] {
+		records = append(records, storeRecord[K, V]{Key: node.key, Value: node.val})
+	}
+
+	return records
+}
+
+func (s *OrderedSyncStore[K, V]) first() *skipNode[K, V] {
+	if s.level == 0 {
+		return nil
+	}
+
+	return s.head.next[0]
+}
+
+// ceiling returns node with the smallest key greater than or equal to key
+func (s *OrderedSyncStore[K, V]) ceiling(key K) *skipNode[K, V] {
+	x := &s.head
+	for lvl := s.level - 1; lvl >= 0; lvl-- {
+		for x.next[lvl] != nil && x.next[lvl].key < key {
+			x = x.next[lvl]
+		}
+	}
+	if s.level == 0 {
+		return nil
+	}
+
+	return x.next[0]
+}
+
+func (s *OrderedSyncStore[K, V]) find(key K) *skipNode[K, V] {
+	if node := s.ceiling(key); node != nil && node.key == key {
+		return node
+	}
+
+	return nil
+}
+
+// predecessors returns the last node with key less than key on every level
+func (s *OrderedSyncStore[K, V]) predecessors(key K) (update [orderedStoreMaxLevel]*skipNode[K, V]) {
+	x := &s.head
+	for lvl := s.level - 1; lvl >= 0; lvl-- {
+		for x.next[lvl] != nil && x.next[lvl].key < key {
+			x = x.next[lvl]
+		}
+		update[lvl] = x
+	}
+
+	return update
+}
+
+func (s *OrderedSyncStore[K, V]) set(key K, val V) (previous V, loaded bool) {
+	if s.head.next == nil {
+		s.head.next = make([]*skipNode[K, V], orderedStoreMaxLevel)
+	}
+
+	update := s.predecessors(key)
+	if s.level > 0 {
+		if node := update[0].next[0]; node != nil && node.key == key {
+			previous, node.val = node.val, val
+			return previous, true
+		}
+	}
+
+	level := min(bits.TrailingZeros64(rand.Uint64())+1, orderedStoreMaxLevel) //nolint:gosec
+	for lvl := s.level; lvl < level; lvl++ {
+		update[lvl] = &s.head
+	}
+	s.level = max(s.level, level)
+
+	node := &skipNode[K, V]{key: key, val: val, next: make([]*skipNode[K, V], level)}
+	for lvl := range level {
+		node.next[lvl] = update[lvl].next[lvl]
+		update[lvl].next[lvl] = node
+	}
+	s.length++
+
+	return previous, false
+}
+
+func (s *OrderedSyncStore[K, V]) remove(key K) (val V, loaded bool) {
+	if s.level == 0 {
+		return val, false
+	}
+
+	update := s.predecessors(key)
+	node := update[0].next[0]
+	if node == nil || node.key != key {
+		return val, false
+	}
+
+	for lvl := range node.next {
+		update[lvl].next[lvl] = node.next[lvl]
+	}
+	for s.level > 0 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.length--
+
+	return node.val, true
+}
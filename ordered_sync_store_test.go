@@ -0,0 +1,262 @@
+package dot_test
+
+import (
+	"context"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirrorru/dot"
+)
+
+func collectPairs[K comparable, V any](seq func(yield func(K, V) bool)) ([]K, []V) {
+	var keys []K
+	var values []V
+	for k, v := range seq {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	return keys, values
+}
+
+func TestOrderedSyncStore_Basic(t *testing.T) {
+	t.Parallel()
+
+	var s dot.OrderedSyncStore[int, string]
+	_, ok := s.GetCurrent(1)
+	assert.False(t, ok)
+	_, _, ok = s.Min()
+	assert.False(t, ok)
+	_, _, ok = s.Max()
+	assert.False(t, ok)
+	s.Del(1)
+
+	s.Put(5, "five")
+	s.Put(1, "one")
+	s.Put(3, "three")
+	s.Put(3, "THREE")
+	assert.Equal(t, 3, s.Len())
+
+	v, ok := s.GetCurrent(3)
+	assert.True(t, ok)
+	assert.Equal(t, "THREE", v)
+
+	assert.Equal(t, "one", s.GetOrPut(1, func() string { return "-" }))
+	assert.Equal(t, "seven", s.GetOrPut(7, func() string { return "seven" }))
+	assert.True(t, dot.CompareAndSwap(&s, 7, "seven", "SEVEN"))
+
+	keys, values := collectPairs(s.Seq2())
+	assert.Equal(t, []int{1, 3, 5, 7}, keys)
+	assert.Equal(t, []string{"one", "THREE", "five", "SEVEN"}, values)
+	assert.Equal(t, values, slices.Collect(s.Seq()))
+
+	v, ok = s.LoadAndDelete(3)
+	assert.True(t, ok)
+	assert.Equal(t, "THREE", v)
+	s.Del(100)
+	assert.Equal(t, 3, s.Len())
+}
+
+func TestOrderedSyncStore_Navigation(t *testing.T) {
+	t.Parallel()
+
+	var s dot.OrderedSyncStore[int, int]
+	for _, k := range []int{10, 20, 30, 40} {
+		s.Put(k, k*10)
+	}
+
+	k, v, ok := s.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 10, k)
+	assert.Equal(t, 100, v)
+
+	k, _, ok = s.Max()
+	assert.True(t, ok)
+	assert.Equal(t, 40, k)
+
+	tests := []struct {
+		name      string
+		key       int
+		floor     int
+		floorOk   bool
+		ceiling   int
+		ceilingOk bool
+	}{
+		{name: "before all", key: 5, ceiling: 10, ceilingOk: true},
+		{name: "exact", key: 20, floor: 20, floorOk: true, ceiling: 20, ceilingOk: true},
+		{name: "between", key: 25, floor: 20, floorOk: true, ceiling: 30, ceilingOk: true},
+		{name: "after all", key: 50, floor: 40, floorOk: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			floor, _, ok := s.Floor(tt.key)
+			assert.Equal(t, tt.floorOk, ok)
+			assert.Equal(t, tt.floor, floor)
+
+			ceiling, _, ok := s.Ceiling(tt.key)
+			assert.Equal(t, tt.ceilingOk, ok)
+			assert.Equal(t, tt.ceiling, ceiling)
+		})
+	}
+
+	keys, _ := collectPairs(s.Range(15, 40))
+	assert.Equal(t, []int{20, 30}, keys)
+	keys, _ = collectPairs(s.Range(40, 15))
+	assert.Empty(t, keys)
+}
+
+func TestOrderedSyncStore_Prefix(t *testing.T) {
+	t.Parallel()
+
+	var s dot.OrderedSyncStore[string, int]
+	for i, k := range []string{"app", "apple", "application", "apt", "banana", "ap"} {
+		s.Put(k, i)
+	}
+
+	keys, _ := collectPairs(dot.OrderedStorePrefix(&s, "app"))
+	assert.Equal(t, []string{"app", "apple", "application"}, keys)
+
+	keys, _ = collectPairs(dot.OrderedStorePrefix(&s, "c"))
+	assert.Empty(t, keys)
+}
+
+func TestOrderedSyncStore_ModifyDuringIteration(t *testing.T) {
+	t.Parallel()
+
+	var s dot.OrderedSyncStore[int, int]
+	for i := range 10 {
+		s.Put(i, i)
+	}
+
+	s.ForEach(func(k, _ int) {
+		s.Del(k)
+	})
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestOrderedSyncStore_Random(t *testing.T) {
+	t.Parallel()
+
+	var s dot.OrderedSyncStore[int, int]
+	expect := map[int]int{}
+	for i := range 2000 {
+		k := rand.IntN(500) //nolint:gosec
+		if i%3 == 0 {
+			s.Del(k)
+			delete(expect, k)
+		} else {
+			s.Put(k, i)
+			expect[k] = i
+		}
+	}
+
+	assert.Equal(t, len(expect), s.Len())
+	keys, _ := collectPairs(s.Seq2())
+	assert.Equal(t, slices.Sorted(maps.Keys(expect)), keys)
+}
+
+func TestOrderedSyncStore_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	var s dot.OrderedSyncStore[int, int]
+	wg := sync.WaitGroup{}
+	for g := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				s.Put(g*100+i, i)
+				s.GetCurrent(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1000, s.Len())
+}
+
+func TestOrderedSyncStore_GetOrPutErr(t *testing.T) {
+	t.Parallel()
+
+	const goroutines = 20
+
+	var s dot.OrderedSyncStore[string, int]
+	res := s.GetOrPutErr("a", func() (int, error) { return 0, assert.AnError })
+	require.ErrorIs(t, res.Err(), assert.AnError)
+	_, ok := s.GetCurrent("a")
+	assert.False(t, ok, "errors must not be stored")
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	started := make(chan struct{})
+	wg := sync.WaitGroup{}
+	results := make([]int, goroutines)
+	wg.Add(goroutines)
+	for i := range goroutines {
+		go func() {
+			defer wg.Done()
+			results[i] = s.GetOrPut("slow", func() int {
+				if calls.Add(1) == 1 {
+					close(started)
+				}
+				<-release
+				return 42
+			})
+		}()
+	}
+
+	<-started
+	s.Put("other", 2)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for _, val := range results {
+		assert.Equal(t, 42, val)
+	}
+	assert.Panics(t, func() {
+		s.GetOrPut("panic", func() int { panic("boom") })
+	})
+	_, ok = s.GetCurrent("panic")
+	assert.False(t, ok)
+}
+
+func TestOrderedSyncStore_Bulk(t *testing.T) {
+	t.Parallel()
+
+	var s dot.OrderedSyncStore[int, int]
+	s.PutAll(map[int]int{1: 10, 2: 20, 3: 30, 4: 40})
+
+	prev, loaded := s.Swap(2, 22)
+	assert.True(t, loaded)
+	assert.Equal(t, 20, prev)
+	_, loaded = s.Swap(5, 50)
+	assert.False(t, loaded)
+
+	assert.Equal(t, 2, s.DeleteIf(func(key, _ int) bool { return key < 4 && key%2 == 1 }))
+	assert.Equal(t, map[int]int{2: 22, 4: 40, 5: 50}, s.ToMap())
+
+	var keys []int
+	for pair := range s.IteratorCtx(context.Background()) {
+		keys = append(keys, pair.Key)
+	}
+	assert.Equal(t, []int{2, 4, 5}, keys)
+
+	s.Clear()
+	assert.Equal(t, 0, s.Len())
+	assert.Empty(t, s.ToMap())
+	_, _, ok := s.Min()
+	assert.False(t, ok)
+	s.Put(7, 70)
+	keys, _ = collectPairs(s.Seq2())
+	assert.Equal(t, []int{7}, keys)
+}
@@ -0,0 +1,147 @@
+package dot
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ErrInvalidCursor - cursor is malformed or signed by another secret
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PageInfo - pagination metadata
+type PageInfo struct {
+	Page       int  `json:"page"` // 1-based page number
+	Size       int  `json:"size"`
+	TotalItems int  `json:"totalItems"`
+	TotalPages int  `json:"totalPages"`
+	HasPrev    bool `json:"hasPrev"`
+	HasNext    bool `json:"hasNext"`
+}
+
+// Page - values of a page with pagination metadata
+type Page[T any] struct {
+	Items []T `json:"items"`
+	PageInfo
+}
+
+// Paginate - returns values of 1-based page of size values, page < 1 means the first page
+// and size < 1 means size 1. Items share memory with source, but appending to them does not affect source.
+// Items are empty for the page after the last one.
+func Paginate[T any](source []T, page, size int) Page[T] {
+	page, size = max(page, 1), max(size, 1)
+	// arithmetic avoids overflow, as page and size may come from untrusted input
+	totalPages := len(source) / size
+	if len(source)%size != 0 {
+		totalPages++
+	}
+	start := len(source)
+	if page <= totalPages {
+		start = (page - 1) * size
+	}
+	end := start + min(size, len(source)-start)
+
+	return Page[T]{
+		Items: source[start:end:end],
+		PageInfo: PageInfo{
+			Page:       page,
+			Size:       size,
+			TotalItems: len(source),
+			TotalPages: totalPages,
+			HasPrev:    page > 1,
+			HasNext:    page < totalPages,
+		},
+	}
+}
+
+// CursorCodec - converts keys to opaque cursors: base64 of JSON-encoded key signed by HMAC-SHA256
+type CursorCodec[K any] struct {
+	secret []byte
+}
+
+// NewCursorCodec - makes codec signing cursors by secret. Panics on empty secret.
+func NewCursorCodec[K any](secret []byte) *CursorCodec[K] {
+	if len(secret) == 0 {
+		panic("dot.NewCursorCodec: secret must not be empty")
+	}
+
+	return &CursorCodec[K]{secret: secret}
+}
+
+// Encode - makes cursor from key
+func (c *CursorCodec[K]) Encode(key K) (string, error) {
+	payload, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(c.sign(payload)), nil
+}
+
+// Decode - returns key of cursor, ErrInvalidCursor for malformed or tampered cursor
+func (c *CursorCodec[K]) Decode(cursor string) (key K, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(data) < sha256.Size {
+		return key, ErrInvalidCursor
+	}
+
+	payload := data[:len(data)-sha256.Size]
+	if !hmac.Equal(c.sign(payload), data) {
+		return key, ErrInvalidCursor
+	}
+	if err = json.Unmarshal(payload, &key); err != nil {
+		return key, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	return key, nil
+}
+
+// sign returns payload followed by its signature
+func (c *CursorCodec[K]) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(payload)
+
+	return mac.Sum(payload[:len(payload):len(payload)])
+}
+
+// CursorSeq - pulls pages by fetch and yields their values. The first page is fetched by start cursor,
+// the next ones by cursor returned with the previous page, zero cursor means there are no more pages.
+// Yields error of fetch or ctx and stops.
+func CursorSeq[T any, C comparable](
+	ctx context.Context, start C, fetch func(ctx context.Context, cursor C) (items []T, next C, err error),
+) iter.Seq[Result[T]] {
+	return func(yield func(Result[T]) bool) {
+		var (
+			zero  C
+			empty T
+		)
+		cursor := start
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(MakeResult(empty, err))
+				return
+			}
+
+			items, next, err := fetch(ctx, cursor)
+			if err != nil {
+				yield(MakeResult(empty, err))
+				return
+			}
+			for _, v := range items {
+				if !yield(MakeResult(v, nil)) {
+					return
+				}
+			}
+
+			if next == zero {
+				return
+			}
+			cursor = next
+		}
+	}
+}
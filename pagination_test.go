@@ -0,0 +1,182 @@
+package dot_test
+
+import (
+	"context"
+	"encoding/base64"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirrorru/dot"
+)
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+
+	source := []int{1, 2, 3, 4, 5}
+	tests := []struct {
+		name       string
+		page, size int
+		items      []int
+		info       dot.PageInfo
+	}{
+		{
+			name: "first", page: 1, size: 2, items: []int{1, 2},
+			info: dot.PageInfo{Page: 1, Size: 2, TotalItems: 5, TotalPages: 3, HasNext: true},
+		},
+		{
+			name: "middle", page: 2, size: 2, items: []int{3, 4},
+			info: dot.PageInfo{Page: 2, Size: 2, TotalItems: 5, TotalPages: 3, HasPrev: true, HasNext: true},
+		},
+		{
+			name: "last", page: 3, size: 2, items: []int{5},
+			info: dot.PageInfo{Page: 3, Size: 2, TotalItems: 5, TotalPages: 3, HasPrev: true},
+		},
+		{
+			name: "after last", page: 4, size: 2, items: []int{},
+			info: dot.PageInfo{Page: 4, Size: 2, TotalItems: 5, TotalPages: 3, HasPrev: true},
+		},
+		{
+			name: "huge page", page: 1 << 62, size: 4, items: []int{},
+			info: dot.PageInfo{Page: 1 << 62, Size: 4, TotalItems: 5, TotalPages: 2, HasPrev: true},
+		},
+		{
+			name: "huge page and size", page: math.MaxInt, size: math.MaxInt, items: []int{},
+			info: dot.PageInfo{Page: math.MaxInt, Size: math.MaxInt, TotalItems: 5, TotalPages: 1, HasPrev: true},
+		},
+		{
+			name: "not positive", page: 0, size: 10, items: []int{1, 2, 3, 4, 5},
+			info: dot.PageInfo{Page: 1, Size: 10, TotalItems: 5, TotalPages: 1},
+		},
+		{
+			name: "not positive size", page: 2, size: 0, items: []int{2},
+			info: dot.PageInfo{Page: 2, Size: 1, TotalItems: 5, TotalPages: 5, HasPrev: true, HasNext: true},
+		},
+		{
+			name: "negative size", page: 1, size: math.MinInt, items: []int{1},
+			info: dot.PageInfo{Page: 1, Size: 1, TotalItems: 5, TotalPages: 5, HasNext: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			page := dot.Paginate(source, tt.page, tt.size)
+			assert.Equal(t, tt.items, page.Items)
+			assert.Equal(t, tt.info, page.PageInfo)
+		})
+	}
+
+	page := dot.Paginate(source, 1, 2)
+	_ = append(page.Items, 100)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, source)
+
+	empty := dot.Paginate([]int(nil), 1, 2)
+	assert.Empty(t, empty.Items)
+	assert.Equal(t, dot.PageInfo{Page: 1, Size: 2}, empty.PageInfo)
+}
+
+type cursorKey struct {
+	ID    int    `json:"id"`
+	Since string `json:"since"`
+}
+
+func TestCursorCodec(t *testing.T) {
+	t.Parallel()
+
+	codec := dot.NewCursorCodec[cursorKey]([]byte("secret"))
+	key := cursorKey{ID: 42, Since: "2024-01-01"}
+
+	cursor, err := codec.Encode(key)
+	require.NoError(t, err)
+	assert.NotContains(t, cursor, "=")
+
+	decoded, err := codec.Decode(cursor)
+	require.NoError(t, err)
+	assert.Equal(t, key, decoded)
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	require.NoError(t, err)
+	data[2] ^= 1
+	_, err = codec.Decode(base64.RawURLEncoding.EncodeToString(data))
+	require.ErrorIs(t, err, dot.ErrInvalidCursor)
+
+	_, err = dot.NewCursorCodec[cursorKey]([]byte("another")).Decode(cursor)
+	require.ErrorIs(t, err, dot.ErrInvalidCursor)
+
+	for _, malformed := range []string{"", "!!!", "c2hvcnQ"} {
+		_, err = codec.Decode(malformed)
+		require.ErrorIs(t, err, dot.ErrInvalidCursor, malformed)
+	}
+
+	// signed payload of another type
+	intCursor, err := dot.NewCursorCodec[string]([]byte("secret")).Encode("text")
+	require.NoError(t, err)
+	_, err = dot.NewCursorCodec[int]([]byte("secret")).Decode(intCursor)
+	require.ErrorIs(t, err, dot.ErrInvalidCursor)
+
+	assert.Panics(t, func() { dot.NewCursorCodec[int](nil) })
+}
+
+// fetchPages serves source by pages of size values with cursors of the next page start
+func fetchPages(source []int, size int, calls *int) func(context.Context, string) ([]int, string, error) {
+	return func(_ context.Context, cursor string) ([]int, string, error) {
+		*calls++
+		start := 0
+		if cursor != "" {
+			var err error
+			if start, err = strconv.Atoi(cursor); err != nil {
+				return nil, "", err
+			}
+		}
+		end := min(start+size, len(source))
+		next := ""
+		if end < len(source) {
+			next = strconv.Itoa(end)
+		}
+
+		return source[start:end], next, nil
+	}
+}
+
+func TestCursorSeq(t *testing.T) {
+	t.Parallel()
+
+	source := []int{1, 2, 3, 4, 5, 6, 7}
+	var calls int
+	var values []int
+	for res := range dot.CursorSeq(t.Context(), "", fetchPages(source, 3, &calls)) {
+		require.False(t, res.IsErr())
+		values = append(values, res.Val())
+	}
+	assert.Equal(t, source, values)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	for res := range dot.CursorSeq(t.Context(), "3", fetchPages(source, 3, &calls)) {
+		if res.Val() == 4 {
+			break
+		}
+	}
+	assert.Equal(t, 1, calls, "must not fetch after break")
+
+	var results []dot.Result[int]
+	for res := range dot.CursorSeq(t.Context(), "bad", fetchPages(source, 3, &calls)) {
+		results = append(results, res)
+	}
+	require.Len(t, results, 1)
+	require.Error(t, results[0].Err())
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	results = results[:0]
+	for res := range dot.CursorSeq(ctx, "", fetchPages(source, 3, &calls)) {
+		results = append(results, res)
+		cancel()
+	}
+	require.Len(t, results, 4)
+	require.ErrorIs(t, results[3].Err(), context.Canceled)
+}
@@ -0,0 +1,33 @@
+package dot
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError - error made from recovered panic
+type PanicError struct {
+	Value any    // value passed to panic
+	Stack []byte // stack trace of the panicked goroutine
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap - returns panic value if it is an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// CallRecover - calls fn, converting its panic into PanicError
+func CallRecover[T any](fn func() (T, error)) (val T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+
+	return fn()
+}
@@ -0,0 +1,33 @@
+package dot_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirrorru/dot"
+)
+
+func TestCallRecover(t *testing.T) {
+	t.Parallel()
+
+	val, err := dot.CallRecover(func() (int, error) { return 1, nil })
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+
+	_, err = dot.CallRecover(func() (int, error) { return 0, assert.AnError })
+	require.ErrorIs(t, err, assert.AnError)
+
+	_, err = dot.CallRecover(func() (int, error) { panic("boom") })
+	var panicErr *dot.PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.Equal(t, "panic: boom", err.Error())
+	assert.NotEmpty(t, panicErr.Stack)
+	assert.NoError(t, errors.Unwrap(err))
+
+	_, err = dot.CallRecover(func() (int, error) { panic(assert.AnError) })
+	require.ErrorIs(t, err, assert.AnError)
+}
@@ -0,0 +1,245 @@
+// Package pipeline provides context-aware building blocks for channel pipelines.
+// Every block stops and closes its output channels when ctx is done or its input is closed,
+// so cancelling ctx releases all goroutines of the pipeline.
+package pipeline
+
+import (
+	"context"
+	"iter"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/mirrorru/dot"
+)
+
+// Generate - sends values of seq as successful results
+func Generate[T any](ctx context.Context, seq iter.Seq[T]) <-chan dot.Result[T] {
+	out := make(chan dot.Result[T])
+	go func() {
+		defer close(out)
+		for v := range seq {
+			if !send(ctx, out, dot.MakeResult(v, nil)) {
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// Stage - converts values of successful results by fn in workers goroutines (at least 1), passing errors through.
+// Results are sent in order of completion. Panics of fn are sent as dot.PanicError.
+func Stage[IN, OUT any](
+	ctx context.Context, in <-chan dot.Result[IN], fn func(ctx context.Context, val IN) (OUT, error), workers int,
+) <-chan dot.Result[OUT] {
+	out := make(chan dot.Result[OUT])
+	var wg sync.WaitGroup
+	wg.Add(max(workers, 1))
+	for range max(workers, 1) {
+		go func() {
+			defer wg.Done()
+			for {
+				src, ok := receive(ctx, in)
+				if !ok {
+					return
+				}
+				res := dot.TransformCtxResult(ctx, src, func(ctx context.Context, val IN) (OUT, error) {
+					return dot.CallRecover(func() (OUT, error) {
+						return fn(ctx, val)
+					})
+				})
+				if !send(ctx, out, res) {
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// FanOut - distributes values among n outputs (at least 1) by single goroutine,
+// every value is sent to the first output ready to receive it
+func FanOut[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	outs := make([]chan T, max(n, 1))
+	result := make([]<-chan T, len(outs))
+	// the first case waits for ctx, the others send the current value to outputs
+	cases := make([]reflect.SelectCase, len(outs)+1)
+	cases[0] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}
+	for i := range outs {
+		outs[i] = make(chan T)
+		result[i] = outs[i]
+		cases[i+1] = reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(outs[i])}
+	}
+
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		for {
+			v, ok := receive(ctx, in)
+			if !ok {
+				return
+			}
+			val := reflect.ValueOf(&v).Elem()
+			for i := range outs {
+				cases[i+1].Send = val
+			}
+			if chosen, _, _ := reflect.Select(cases); chosen == 0 {
+				return
+			}
+		}
+	}()
+
+	return result
+}
+
+// FanIn - merges values of all inputs into single output, closed after all inputs are closed
+func FanIn[T any](ctx context.Context, ins ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(len(ins))
+	for _, in := range ins {
+		go func() {
+			defer wg.Done()
+			for {
+				v, ok := receive(ctx, in)
+				if !ok || !send(ctx, out, v) {
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// Tee - sends every value to both outputs, the next value is taken after both outputs received the current one
+func Tee[T any](ctx context.Context, in <-chan T) (<-chan T, <-chan T) {
+	out1, out2 := make(chan T), make(chan T)
+	go func() {
+		defer close(out1)
+		defer close(out2)
+		for {
+			v, ok := receive(ctx, in)
+			if !ok {
+				return
+			}
+			first, second := out1, out2
+			for range 2 {
+				select {
+				case <-ctx.Done():
+					return
+				case first <- v:
+					first = nil
+				case second <- v:
+					second = nil
+				}
+			}
+		}
+	}()
+
+	return out1, out2
+}
+
+// Batch - groups values into batches of up to size values (at least 1).
+// Incomplete batch is sent after maxWait since its first value, zero maxWait means waiting for size values.
+// The rest values are sent as the last batch when the input is closed.
+func Batch[T any](ctx context.Context, in <-chan T, size int, maxWait time.Duration) <-chan []T {
+	size = max(size, 1)
+	out := make(chan []T)
+	go func() {
+		defer close(out)
+
+		var timeout <-chan time.Time
+		timer := time.NewTimer(maxWait)
+		timer.Stop()
+		defer timer.Stop()
+
+		batch := make([]T, 0, size)
+		flush := func() bool {
+			timer.Stop()
+			timeout = nil
+			if len(batch) == 0 {
+				return true
+			}
+			ok := send(ctx, out, batch)
+			batch = make([]T, 0, size)
+			return ok
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+				batch = append(batch, v)
+				if len(batch) == size {
+					if !flush() {
+						return
+					}
+				} else if len(batch) == 1 && maxWait > 0 {
+					timer.Reset(maxWait)
+					timeout = timer.C
+				}
+			case <-timeout:
+				if !flush() {
+					return
+				}
+			}
+		}
+	}()
+
+	return out
+}
+
+// OrDone - forwards values of in until it is closed or ctx is done
+func OrDone[T any](ctx context.Context, in <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			v, ok := receive(ctx, in)
+			if !ok || !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// receive receives value from in, false if in is closed or ctx is done before
+func receive[T any](ctx context.Context, in <-chan T) (val T, ok bool) {
+	select {
+	case <-ctx.Done():
+		return val, false
+	case val, ok = <-in:
+		return val, ok
+	}
+}
+
+// send sends value to out, false if ctx is done before
+func send[T any](ctx context.Context, out chan<- T, val T) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case out <- val:
+		return true
+	}
+}
@@ -0,0 +1,226 @@
+package pipeline_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirrorru/dot"
+	"github.com/mirrorru/dot/pipeline"
+)
+
+// checkNoLeaks fails the test if goroutines started by it are still running at its end.
+// Tests using it must not be parallel, so paused parallel tests do not affect the count.
+func checkNoLeaks(t *testing.T) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		// polls in place, assert.Eventually starts own goroutines
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		assert.LessOrEqual(t, runtime.NumGoroutine(), before, "goroutines leaked")
+	})
+}
+
+func collect[T any](in <-chan T) []T {
+	var result []T
+	for v := range in {
+		result = append(result, v)
+	}
+
+	return result
+}
+
+func TestGenerateStage(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	src := pipeline.Generate(ctx, slices.Values([]int{1, 2, 3, 4, 5}))
+	out := pipeline.Stage(ctx, src, func(_ context.Context, v int) (string, error) {
+		if v == 3 {
+			return "", assert.AnError
+		}
+		return strconv.Itoa(v * 10), nil
+	}, 3)
+
+	var vals []string
+	var errs []error
+	for res := range out {
+		if res.IsErr() {
+			errs = append(errs, res.Err())
+			continue
+		}
+		vals = append(vals, res.Val())
+	}
+	assert.ElementsMatch(t, []string{"10", "20", "40", "50"}, vals)
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], assert.AnError)
+}
+
+func TestStage_ErrorsAndPanics(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	in := make(chan dot.Result[int], 2)
+	in <- dot.MakeResult(0, assert.AnError)
+	in <- dot.MakeResult(1, nil)
+	close(in)
+
+	var fnCalls int
+	results := collect(pipeline.Stage(ctx, in, func(context.Context, int) (int, error) {
+		fnCalls++
+		panic("boom")
+	}, 0))
+	require.Len(t, results, 2)
+	assert.Equal(t, 1, fnCalls)
+
+	require.ErrorIs(t, results[0].Err(), assert.AnError)
+	var panicErr *dot.PanicError
+	require.ErrorAs(t, results[1].Err(), &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+}
+
+func TestFanOutFanIn(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for i := range 100 {
+			in <- i
+		}
+	}()
+
+	outs := pipeline.FanOut(ctx, in, 4)
+	require.Len(t, outs, 4)
+	merged := collect(pipeline.FanIn(ctx, outs...))
+	slices.Sort(merged)
+	expected := make([]int, 100)
+	for i := range expected {
+		expected[i] = i
+	}
+	assert.Equal(t, expected, merged)
+
+	assert.Empty(t, collect(pipeline.FanIn[int](ctx)))
+}
+
+func TestFanOut_IdleOutput(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for i := range 100 {
+			in <- i
+		}
+	}()
+	outs := pipeline.FanOut(ctx, in, 2)
+
+	// nobody reads outs[0], so every value goes to outs[1]
+	assert.Len(t, collect(outs[1]), 100)
+	_, ok := <-outs[0]
+	assert.False(t, ok)
+}
+
+func TestTee(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	out1, out2 := pipeline.Tee(ctx, pipeline.OrDone(ctx, makeChan(1, 2, 3)))
+
+	var wg sync.WaitGroup
+	var vals1, vals2 []int
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		vals1 = collect(out1)
+	}()
+	go func() {
+		defer wg.Done()
+		vals2 = collect(out2)
+	}()
+	wg.Wait()
+
+	assert.Equal(t, []int{1, 2, 3}, vals1)
+	assert.Equal(t, []int{1, 2, 3}, vals2)
+}
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, collect(pipeline.Batch(ctx, makeChan(1, 2, 3, 4, 5), 2, 0)))
+	assert.Empty(t, collect(pipeline.Batch(ctx, makeChan[int](), 2, time.Second)))
+
+	in := make(chan int)
+	out := pipeline.Batch(ctx, in, 10, 10*time.Millisecond)
+	in <- 1
+	in <- 2
+	assert.Equal(t, []int{1, 2}, <-out)
+	in <- 3
+	close(in)
+	assert.Equal(t, []int{3}, <-out)
+	_, ok := <-out
+	assert.False(t, ok)
+}
+
+func TestPipeline_CancelNoLeaks(t *testing.T) { //nolint:paralleltest // counts goroutines
+	checkNoLeaks(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	infinite := func(yield func(int) bool) {
+		for i := 0; yield(i); i++ { //nolint:revive
+		}
+	}
+
+	results := pipeline.Stage(ctx, pipeline.Generate(ctx, infinite), func(_ context.Context, v int) (int, error) {
+		return v * 2, nil
+	}, 4)
+	outs := pipeline.FanOut(ctx, results, 3)
+	first, second := pipeline.Tee(ctx, pipeline.FanIn(ctx, outs...))
+	batches := pipeline.Batch(ctx, pipeline.OrDone(ctx, first), 5, time.Millisecond)
+
+	// consume a little and abandon outputs without draining
+	<-batches
+	<-second
+	cancel()
+}
+
+func TestPipeline_ClosedInputNoLeaks(t *testing.T) { //nolint:paralleltest // counts goroutines
+	checkNoLeaks(t)
+
+	ctx := context.Background()
+	results := pipeline.Stage(ctx, pipeline.Generate(ctx, slices.Values([]int{1, 2, 3})),
+		func(_ context.Context, v int) (int, error) {
+			if v == 2 {
+				return 0, errors.New("two")
+			}
+			return v, nil
+		}, 2)
+	first, second := pipeline.Tee(ctx, pipeline.FanIn(ctx, pipeline.FanOut(ctx, results, 2)...))
+	go collect(second)
+
+	assert.Len(t, collect(pipeline.Batch(ctx, first, 2, time.Millisecond)), 2)
+}
+
+func makeChan[T any](vals ...T) <-chan T {
+	ch := make(chan T, len(vals))
+	for _, v := range vals {
+		ch <- v
+	}
+	close(ch)
+
+	return ch
+}
@@ -0,0 +1,224 @@
+package dot
+
+import (
+	"container/heap"
+	"iter"
+	"sync"
+)
+
+// PriorityHandle - reference to value pushed into PriorityQueue, used for Update and Remove
+type PriorityHandle[T any] struct {
+	val   T
+	seq   uint64 // push order, orders equal values
+	index int    // position in heap, -1 after removal
+}
+
+// PriorityQueue - queue popping values in order of comparator, not concurrency-safe, see SyncPriorityQueue.
+// Equal values are popped in order of pushing.
+type PriorityQueue[T any] struct {
+	items priorityHeap[T]
+	seq   uint64
+}
+
+// NewPriorityQueue - makes queue popping values going first by cmp first
+func NewPriorityQueue[T any](cmp Comparator[T]) *PriorityQueue[T] {
+	return &PriorityQueue[T]{items: priorityHeap[T]{cmp: cmp}}
+}
+
+// Push - adds value, returns its handle
+func (q *PriorityQueue[T]) Push(val T) *PriorityHandle[T] {
+	h := &PriorityHandle[T]{val: val, seq: q.seq}
+	q.seq++
+	heap.Push(&q.items, h)
+
+	return h
+}
+
+// Pop - removes and returns the first value, false for empty queue
+func (q *PriorityQueue[T]) Pop() (val T, ok bool) {
+	if len(q.items.handles) == 0 {
+		return val, false
+	}
+
+	return heap.Pop(&q.items).(*PriorityHandle[T]).val, true //nolint:forcetypeassert
+}
+
+// Peek - returns the first value without removing, false for empty queue
+func (q *PriorityQueue[T]) Peek() (val T, ok bool) {
+	if len(q.items.handles) == 0 {
+		return val, false
+	}
+
+	return q.items.handles[0].val, true
+}
+
+// Value - returns value by handle, false if the value is not in the queue
+func (q *PriorityQueue[T]) Value(h *PriorityHandle[T]) (val T, ok bool) {
+	if !q.contains(h) {
+		return val, false
+	}
+
+	return h.val, true
+}
+
+// Update - replaces value by handle and restores order, false if the value is not in the queue
+func (q *PriorityQueue[T]) Update(h *PriorityHandle[T], val T) bool {
+	if !q.contains(h) {
+		return false
+	}
+
+	h.val = val
+	heap.Fix(&q.items, h.index)
+
+	return true
+}
+
+// Remove - removes value by handle, false if the value is not in the queue
+func (q *PriorityQueue[T]) Remove(h *PriorityHandle[T]) bool {
+	if !q.contains(h) {
+		return false
+	}
+
+	heap.Remove(&q.items, h.index)
+
+	return true
+}
+
+func (q *PriorityQueue[T]) Len() int {
+	return len(q.items.handles)
+}
+
+// Drain - pops values in priority order until the queue is empty
+func (q *PriorityQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			val, ok := q.Pop()
+			if !ok || !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+func (q *PriorityQueue[T]) contains(h *PriorityHandle[T]) bool {
+	return h != nil && h.index >= 0 && h.index < len(q.items.handles) && q.items.handles[h.index] == h
+}
+
+// SyncPriorityQueue - concurrency-safe PriorityQueue. It must not be copied after first use.
+type SyncPriorityQueue[T any] struct {
+	mx    sync.Mutex
+	queue PriorityQueue[T]
+}
+
+// NewSyncPriorityQueue - makes queue popping values going first by cmp first
+func NewSyncPriorityQueue[T any](cmp Comparator[T]) *SyncPriorityQueue[T] {
+	return &SyncPriorityQueue[T]{queue: PriorityQueue[T]{items: priorityHeap[T]{cmp: cmp}}}
+}
+
+// Push - adds value, returns its handle
+func (q *SyncPriorityQueue[T]) Push(val T) *PriorityHandle[T] {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	return q.queue.Push(val)
+}
+
+// Pop - removes and returns the first value, false for empty queue
+func (q *SyncPriorityQueue[T]) Pop() (val T, ok bool) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	return q.queue.Pop()
+}
+
+// Peek - returns the first value without removing, false for empty queue
+func (q *SyncPriorityQueue[T]) Peek() (val T, ok bool) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	return q.queue.Peek()
+}
+
+// Value - returns value by handle, false if the value is not in the queue
+func (q *SyncPriorityQueue[T]) Value(h *PriorityHandle[T]) (val T, ok bool) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	return q.queue.Value(h)
+}
+
+// Update - replaces value by handle and restores order, false if the value is not in the queue
+func (q *SyncPriorityQueue[T]) Update(h *PriorityHandle[T], val T) bool {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	return q.queue.Update(h, val)
+}
+
+// Remove - removes value by handle, false if the value is not in the queue
+func (q *SyncPriorityQueue[T]) Remove(h *PriorityHandle[T]) bool {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	return q.queue.Remove(h)
+}
+
+func (q *SyncPriorityQueue[T]) Len() int {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	return q.queue.Len()
+}
+
+// Drain - pops values in priority order until the queue is empty, the lock is held only while popping
+func (q *SyncPriorityQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			val, ok := q.Pop()
+			if !ok || !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// priorityHeap - heap of handles ordered by comparator and push order
+type priorityHeap[T any] struct {
+	cmp     Comparator[T]
+	handles []*PriorityHandle[T]
+}
+
+func (h *priorityHeap[T]) Len() int {
+	return len(h.handles)
+}
+
+func (h *priorityHeap[T]) Less(i, j int) bool {
+	a, b := h.handles[i], h.handles[j]
+	if res := h.cmp(a.val, b.val); res != 0 {
+		return res < 0
+	}
+
+	return a.seq < b.seq
+}
+
+func (h *priorityHeap[T]) Swap(i, j int) {
+	h.handles[i], h.handles[j] = h.handles[j], h.handles[i]
+	h.handles[i].index = i
+	h.handles[j].index = j
+}
+
+func (h *priorityHeap[T]) Push(x any) {
+	handle := x.(*PriorityHandle[T]) //nolint:forcetypeassert
+	handle.index = len(h.handles)
+	h.handles = append(h.handles, handle)
+}
+
+func (h *priorityHeap[T]) Pop() any {
+	last := len(h.handles) - 1
+	handle := h.handles[last]
+	h.handles[last] = nil
+	h.handles = h.handles[:last]
+	handle.index = -1
+
+	return handle
+}
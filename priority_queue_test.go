@@ -0,0 +1,118 @@
+package dot_test
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mirrorru/dot"
+)
+
+type task struct {
+	Name     string
+	Priority int
+}
+
+func TestPriorityQueue(t *testing.T) {
+	t.Parallel()
+
+	q := dot.NewPriorityQueue(dot.By(func(t task) int { return t.Priority }).Reverse())
+	_, ok := q.Pop()
+	assert.False(t, ok)
+
+	q.Push(task{"low", 1})
+	mid := q.Push(task{"mid", 5})
+	high := q.Push(task{"high", 9})
+	q.Push(task{"mid2", 5})
+	gone := q.Push(task{"gone", 7})
+	assert.Equal(t, 5, q.Len())
+
+	val, ok := q.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, "high", val.Name)
+
+	assert.True(t, q.Remove(gone))
+	assert.False(t, q.Remove(gone))
+	assert.False(t, q.Update(gone, task{"gone", 100}))
+	_, ok = q.Value(gone)
+	assert.False(t, ok)
+
+	assert.True(t, q.Update(mid, task{"mid", 10}))
+	val, ok = q.Value(mid)
+	assert.True(t, ok)
+	assert.Equal(t, 10, val.Priority)
+
+	val, _ = q.Pop()
+	assert.Equal(t, "mid", val.Name)
+	assert.False(t, q.Update(mid, task{"mid", 0}))
+	assert.True(t, q.Update(high, task{"high", 0}))
+
+	var order []string
+	for v := range q.Drain() {
+		order = append(order, v.Name)
+	}
+	assert.Equal(t, []string{"mid2", "low", "high"}, order)
+	assert.Equal(t, 0, q.Len())
+	assert.False(t, q.Remove(nil))
+}
+
+func TestPriorityQueue_EqualValuesFIFO(t *testing.T) {
+	t.Parallel()
+
+	q := dot.NewPriorityQueue(dot.Comparator[int](cmp.Compare[int]))
+	for _, v := range []int{3, 1, 2} {
+		q.Push(v)
+	}
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(q.Drain()))
+
+	byPriority := dot.NewPriorityQueue(dot.By(func(t task) int { return t.Priority }))
+	for _, name := range []string{"a", "b", "c"} {
+		byPriority.Push(task{name, 1})
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, taskNames(slices.Collect(byPriority.Drain())))
+}
+
+func taskNames(tasks []task) []string {
+	return dot.SliceToSlice(tasks, func(t task) string { return t.Name })
+}
+
+func TestSyncPriorityQueue(t *testing.T) {
+	t.Parallel()
+
+	q := dot.NewSyncPriorityQueue(dot.Comparator[int](cmp.Compare[int]))
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				h := q.Push(i*100 + j)
+				if j%2 == 0 {
+					assert.True(t, q.Update(h, -queueValue(q, h)))
+				}
+				if j%10 == 0 {
+					assert.True(t, q.Remove(h))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 900, q.Len())
+
+	first, ok := q.Peek()
+	assert.True(t, ok)
+	drained := slices.Collect(q.Drain())
+	assert.Len(t, drained, 900)
+	assert.Equal(t, first, drained[0])
+	assert.True(t, slices.IsSorted(drained))
+	_, ok = q.Pop()
+	assert.False(t, ok)
+}
+
+func queueValue(q *dot.SyncPriorityQueue[int], h *dot.PriorityHandle[int]) int {
+	val, _ := q.Value(h)
+	return val
+}
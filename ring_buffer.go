@@ -0,0 +1,152 @@
+package dot
+
+import (
+	"iter"
+	"sync"
+)
+
+// RingBuffer - concurrency-safe fixed capacity buffer, overwriting the oldest value when full.
+// The zero value is an empty buffer with capacity 1.
+type RingBuffer[T any] struct {
+	mx    sync.Mutex
+	items ring[T]
+}
+
+func NewRingBuffer[T any](capacity int) *RingBuffer[T] {
+	return &RingBuffer[T]{items: makeRing[T](capacity)}
+}
+
+// Push - appends value, overwriting the oldest one if the buffer is full.
+// Returns true if a value was overwritten.
+func (r *RingBuffer[T]) Push(val T) (overwritten bool) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if r.items.buf == nil {
+		r.items = makeRing[T](1)
+	}
+	if r.items.full() {
+		r.items.pop()
+		overwritten = true
+	}
+	r.items.push(val)
+
+	return overwritten
+}
+
+// Pop - removes and returns the oldest value, false for empty buffer
+func (r *RingBuffer[T]) Pop() (val T, ok bool) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if r.items.length == 0 {
+		return val, false
+	}
+
+	return r.items.pop(), true
+}
+
+func (r *RingBuffer[T]) Len() int {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	return r.items.length
+}
+
+func (r *RingBuffer[T]) Cap() int {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	return max(len(r.items.buf), 1)
+}
+
+// Values - returns copy of values from the oldest to the newest
+func (r *RingBuffer[T]) Values() []T {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	return r.items.values()
+}
+
+// Seq - iterates over copy of values from the oldest to the newest
+func (r *RingBuffer[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range r.Values() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ring - fixed capacity FIFO, not concurrency-safe
+type ring[T any] struct {
+	buf    []T
+	head   int // index of the oldest value
+	length int
+}
+
+func makeRing[T any](capacity int) ring[T] {
+	return ring[T]{buf: make([]T, max(capacity, 1))}
+}
+
+func (r *ring[T]) full() bool {
+	return r.length == len(r.buf)
+}
+
+// push appends value, the ring must not be full
+func (r *ring[T]) push(val T) {
+	r.buf[(r.head+r.length)%len(r.buf)] = val
+	r.length++
+}
+
+// pop removes the oldest value, the ring must not be empty
+func (r *ring[T]) pop() T {
+	var empty T
+	val := r.buf[r.head]
+	r.buf[r.head] = empty
+	r.head = (r.head + 1) % len(r.buf)
+	r.length--
+
+	return val
+}
+
+func (r *ring[T]) values() []T {
+	result := make([]T, r.length)
+	for i := range result {
+		result[i] = r.buf[(r.head+i)%len(r.buf)]
+	}
+
+	return result
+}
+
+// pushFront prepends value, the ring must not be full
+func (r *ring[T]) pushFront(val T) {
+	r.head = (r.head - 1 + len(r.buf)) % len(r.buf)
+	r.buf[r.head] = val
+	r.length++
+}
+
+// popBack removes the newest value, the ring must not be empty
+func (r *ring[T]) popBack() T {
+	var empty T
+	index := (r.head + r.length - 1) % len(r.buf)
+	val := r.buf[index]
+	r.buf[index] = empty
+	r.length--
+
+	return val
+}
+
+// at returns value by position from the oldest one, position must be in range
+func (r *ring[T]) at(pos int) T {
+	return r.buf[(r.head+pos)%len(r.buf)]
+}
+
+// grow doubles capacity keeping values
+func (r *ring[T]) grow() {
+	values := r.values()
+	r.buf = make([]T, max(2*len(r.buf), 8))
+	copy(r.buf, values)
+	r.head = 0
+}
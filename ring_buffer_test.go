@@ -0,0 +1,66 @@
+package dot_test
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mirrorru/dot"
+)
+
+func TestRingBuffer(t *testing.T) {
+	t.Parallel()
+
+	r := dot.NewRingBuffer[int](3)
+	assert.Equal(t, 3, r.Cap())
+	_, ok := r.Pop()
+	assert.False(t, ok)
+
+	assert.False(t, r.Push(1))
+	assert.False(t, r.Push(2))
+	assert.False(t, r.Push(3))
+	assert.True(t, r.Push(4))
+	assert.Equal(t, 3, r.Len())
+	assert.Equal(t, []int{2, 3, 4}, r.Values())
+	assert.Equal(t, []int{2, 3, 4}, slices.Collect(r.Seq()))
+
+	val, ok := r.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 2, val)
+	r.Push(5)
+	r.Push(6)
+	assert.Equal(t, []int{4, 5, 6}, r.Values())
+}
+
+func TestRingBuffer_ZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var r dot.RingBuffer[int]
+	assert.Equal(t, 1, r.Cap())
+	assert.Empty(t, r.Values())
+	assert.False(t, r.Push(1))
+	assert.True(t, r.Push(2))
+	assert.Equal(t, []int{2}, r.Values())
+}
+
+func TestRingBuffer_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	r := dot.NewRingBuffer[int](10)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				r.Push(i)
+				r.Pop()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 0, r.Len())
+}
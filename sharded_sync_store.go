@@ -0,0 +1,269 @@
+package dot
+
+import (
+	"context"
+	"hash/maphash"
+	"iter"
+	"maps"
+	"runtime"
+)
+
+// ShardedSyncStore - SyncStore partitioned into independently locked shards to reduce lock contention.
+// Operations on single key lock only its shard. Operations on the whole store visit shards one by one,
+// so they are not atomic across shards.
+// Unlike SyncStore, the zero value is not usable: make the store by NewShardedSyncStore.
+type ShardedSyncStore[K comparable, V any] struct {
+	shards []SyncStore[K, V]
+	hasher func(key K) uint64
+}
+
+// NewShardedSyncStore - makes store with specified shards count (GOMAXPROCS*4 for non-positive count)
+// and hasher. Nil hasher means hashing by hash/maphash.
+func NewShardedSyncStore[K comparable, V any](shardsCount int, hasher func(key K) uint64) *ShardedSyncStore[K, V] {
+	if shardsCount <= 0 {
+		shardsCount = runtime.GOMAXPROCS(0) * 4 //nolint:mnd
+	}
+	if hasher == nil {
+		hasher = MaphashHasher[K]()
+	}
+
+	return &ShardedSyncStore[K, V]{
+		shards: make([]SyncStore[K, V], shardsCount),
+		hasher: hasher,
+	}
+}
+
+// MaphashHasher - returns hash/maphash based hasher with random seed
+func MaphashHasher[K comparable]() func(key K) uint64 {
+	seed := maphash.MakeSeed()
+	return func(key K) uint64 {
+		return maphash.Comparable(seed, key)
+	}
+}
+
+func (s *ShardedSyncStore[K, V]) shard(key K) *SyncStore[K, V] {
+	return &s.shards[s.shardIndex(key)]
+}
+
+func (s *ShardedSyncStore[K, V]) shardIndex(key K) int {
+	return int(s.hasher(key) % uint64(len(s.shards))) //nolint:gosec
+}
+
+// Preallocate - init internal maps with total specified size
+func (s *ShardedSyncStore[K, V]) Preallocate(mapSize int) {
+	shardSize := mapSize/len(s.shards) + 1
+	for i := range s.shards {
+		s.shards[i].Preallocate(shardSize)
+	}
+}
+
+func (s *ShardedSyncStore[K, V]) Put(key K, val V) {
+	s.shard(key).Put(key, val)
+}
+
+func (s *ShardedSyncStore[K, V]) GetCurrent(key K) (val V, founded bool) {
+	return s.shard(key).GetCurrent(key)
+}
+
+// GetOrPut - see SyncStore.GetOrPut
+func (s *ShardedSyncStore[K, V]) GetOrPut(key K, maker func() V) V {
+	return s.shard(key).GetOrPut(key, maker)
+}
+
+// GetOrPutErr - see SyncStore.GetOrPutErr
+func (s *ShardedSyncStore[K, V]) GetOrPutErr(key K, maker func() (V, error)) Result[V] {
+	return s.shard(key).GetOrPutErr(key, maker)
+}
+
+func (s *ShardedSyncStore[K, V]) Del(key K) {
+	s.shard(key).Del(key)
+}
+
+// Update - see SyncStore.Update
+func (s *ShardedSyncStore[K, V]) Update(key K, updater func(old V, found bool) (V, bool)) (val V, found bool) {
+	return s.shard(key).Update(key, updater)
+}
+
+// Swap - stores value and returns the previous one, if any
+func (s *ShardedSyncStore[K, V]) Swap(key K, val V) (previous V, loaded bool) {
+	return s.shard(key).Swap(key, val)
+}
+
+// LoadAndDelete - deletes key and returns its previous value, if any
+func (s *ShardedSyncStore[K, V]) LoadAndDelete(key K) (val V, loaded bool) {
+	return s.shard(key).LoadAndDelete(key)
+}
+
+// DeleteIf - deletes all pairs matched by predicate and returns count of deleted pairs
+func (s *ShardedSyncStore[K, V]) DeleteIf(predicate func(key K, value V) bool) (deleted int) {
+	for i := range s.shards {
+		deleted += s.shards[i].DeleteIf(predicate)
+	}
+
+	return deleted
+}
+
+// PutAll - stores all pairs of m, locking every shard once
+func (s *ShardedSyncStore[K, V]) PutAll(m map[K]V) {
+	parts := make([]map[K]V, len(s.shards))
+	for k, v := range m {
+		idx := s.shardIndex(k)
+		if parts[idx] == nil {
+			parts[idx] = make(map[K]V)
+		}
+		parts[idx][k] = v
+	}
+	for idx, part := range parts {
+		if part != nil {
+			s.shards[idx].PutAll(part)
+		}
+	}
+}
+
+// PutSeq - stores all pairs of seq, locking every shard once
+func (s *ShardedSyncStore[K, V]) PutSeq(seq iter.Seq2[K, V]) {
+	m := make(map[K]V)
+	for k, v := range seq {
+		m[k] = v
+	}
+	s.PutAll(m)
+}
+
+// GetMany - returns found pairs of keys
+func (s *ShardedSyncStore[K, V]) GetMany(keys ...K) map[K]V {
+	result := make(map[K]V, len(keys))
+	for idx, part := range s.splitKeys(keys) {
+		if part != nil {
+			maps.Copy(result, s.shards[idx].GetMany(part...))
+		}
+	}
+
+	return result
+}
+
+// DeleteMany - deletes keys and returns count of deleted pairs
+func (s *ShardedSyncStore[K, V]) DeleteMany(keys ...K) (deleted int) {
+	for idx, part := range s.splitKeys(keys) {
+		if part != nil {
+			deleted += s.shards[idx].DeleteMany(part...)
+		}
+	}
+
+	return deleted
+}
+
+// Clear - deletes all pairs, shard by shard
+func (s *ShardedSyncStore[K, V]) Clear() {
+	for i := range s.shards {
+		s.shards[i].Clear()
+	}
+}
+
+func (s *ShardedSyncStore[K, V]) splitKeys(keys []K) [][]K {
+	parts := make([][]K, len(s.shards))
+	for _, k := range keys {
+		idx := s.shardIndex(k)
+		parts[idx] = append(parts[idx], k)
+	}
+
+	return parts
+}
+
+// Len - returns count of stored pairs
+func (s *ShardedSyncStore[K, V]) Len() (length int) {
+	for i := range s.shards {
+		length += s.shards[i].Len()
+	}
+
+	return length
+}
+
+// ForEach - calls handler for every pair, holding the read lock of the current shard
+func (s *ShardedSyncStore[K, V]) ForEach(handler func(key K, value V)) {
+	for i := range s.shards {
+		s.shards[i].ForEach(handler)
+	}
+}
+
+// ForEachSnapshot - calls handler for every pair of the store copy, see SyncStore.ForEachSnapshot
+func (s *ShardedSyncStore[K, V]) ForEachSnapshot(handler func(key K, value V)) {
+	for k, v := range s.ToMap() {
+		handler(k, v)
+	}
+}
+
+// ToMap - returns copy of stored pairs. Shards are copied one by one.
+func (s *ShardedSyncStore[K, V]) ToMap() map[K]V {
+	result := make(map[K]V)
+	for i := range s.shards {
+		maps.Copy(result, s.shards[i].ToMap())
+	}
+
+	return result
+}
+
+// Iterator - see SyncStore.Iterator
+//
+// Deprecated: use IteratorCtx.
+func (s *ShardedSyncStore[K, V]) Iterator() <-chan struct {
+	Key   K
+	Value V
+} {
+	return s.IteratorCtx(context.Background())
+}
+
+// IteratorCtx - see SyncStore.IteratorCtx
+func (s *ShardedSyncStore[K, V]) IteratorCtx(ctx context.Context) <-chan struct {
+	Key   K
+	Value V
+} {
+	return pairsChan(ctx, maps.All(s.ToMap()))
+}
+
+// Seq - iterates over values, holding the read lock of the current shard
+func (s *ShardedSyncStore[K, V]) Seq() func(yield func(V) bool) {
+	return func(yield func(V) bool) {
+		for i := range s.shards {
+			for v := range s.shards[i].Seq() {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Seq2 - iterates over keys and values, holding the read lock of the current shard
+func (s *ShardedSyncStore[K, V]) Seq2() func(yield func(K, V) bool) {
+	return func(yield func(K, V) bool) {
+		for i := range s.shards {
+			for k, v := range s.shards[i].Seq2() {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SnapshotSeq - iterates over values of the store copy, see SyncStore.SnapshotSeq
+func (s *ShardedSyncStore[K, V]) SnapshotSeq() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range s.ToMap() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SnapshotSeq2 - iterates over pairs of the store copy, see SyncStore.SnapshotSeq2
+func (s *ShardedSyncStore[K, V]) SnapshotSeq2() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range s.ToMap() {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package dot_test
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirrorru/dot"
+)
+
+func TestShardedSyncStore_Basic(t *testing.T) {
+	t.Parallel()
+
+	s := dot.NewShardedSyncStore[string, int](4, nil)
+	s.Preallocate(100)
+	s.Put("a", 1)
+	s.Put("b", 2)
+
+	v, ok := s.GetCurrent("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	assert.Equal(t, 2, s.GetOrPut("b", func() int { return -1 }))
+	res := s.GetOrPutErr("c", func() (int, error) { return 3, nil })
+	require.NoError(t, res.Err())
+	assert.Equal(t, 3, res.Val())
+	assert.Equal(t, 3, s.Len())
+
+	assert.True(t, dot.CompareAndSwap(s, "c", 3, 4))
+	prev, loaded := s.Swap("c", 5)
+	assert.True(t, loaded)
+	assert.Equal(t, 4, prev)
+
+	v, loaded = s.LoadAndDelete("c")
+	assert.True(t, loaded)
+	assert.Equal(t, 5, v)
+
+	s.Del("a")
+	_, ok = s.GetCurrent("a")
+	assert.False(t, ok)
+	assert.Equal(t, 1, s.Len())
+}
+
+func TestShardedSyncStore_Iteration(t *testing.T) {
+	t.Parallel()
+
+	s := dot.NewShardedSyncStore[int, int](0, func(key int) uint64 { return uint64(key) })
+	expect := map[int]int{}
+	for i := range 100 {
+		s.Put(i, i*i)
+		expect[i] = i * i
+	}
+
+	got := map[int]int{}
+	s.ForEach(func(k, v int) {
+		got[k] = v
+	})
+	assert.Equal(t, expect, got)
+
+	got = map[int]int{}
+	for k, v := range s.Seq2() {
+		got[k] = v
+	}
+	assert.Equal(t, expect, got)
+
+	got = map[int]int{}
+	for pair := range s.Iterator() {
+		got[pair.Key] = pair.Value
+	}
+	assert.Equal(t, expect, got)
+
+	count := 0
+	for range s.Seq() {
+		count++
+		if count == 10 {
+			break
+		}
+	}
+	assert.Equal(t, 10, count)
+
+	assert.Equal(t, 50, s.DeleteIf(func(k, _ int) bool { return k%2 == 0 }))
+	assert.Equal(t, 50, s.Len())
+}
+
+func TestShardedSyncStore_SnapshotIteration(t *testing.T) {
+	t.Parallel()
+
+	s := dot.NewShardedSyncStore[int, int](4, nil)
+	for i := range 10 {
+		s.Put(i, i)
+	}
+
+	// modifications inside loops must not deadlock and must not affect running iteration
+	count := 0
+	for k, v := range s.SnapshotSeq2() {
+		s.Put(k+100, v+100)
+		count++
+	}
+	assert.Equal(t, 10, count)
+	assert.Equal(t, 20, s.Len())
+
+	count = 0
+	for v := range s.SnapshotSeq() {
+		s.Del(v)
+		count++
+	}
+	assert.Equal(t, 20, count)
+	assert.Equal(t, 0, s.Len())
+
+	s.Put(1, 1)
+	s.ForEachSnapshot(func(k, v int) {
+		s.Put(k+1, v+1)
+	})
+	assert.Equal(t, map[int]int{1: 1, 2: 2}, s.ToMap())
+
+	// early break
+	for range 5 {
+		s.Put(count, count)
+		count++
+	}
+	count = 0
+	for range s.SnapshotSeq2() {
+		count++
+		break
+	}
+	for range s.SnapshotSeq() {
+		count++
+		break
+	}
+	assert.Equal(t, 2, count)
+}
+
+func TestShardedSyncStore_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	s := dot.NewShardedSyncStore[int, int](8, nil)
+	wg := sync.WaitGroup{}
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				s.Put(i, i*i)
+				s.Update(-1, func(old int, _ bool) (int, bool) { return old + 1, true })
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 101, s.Len())
+	v, _ := s.GetCurrent(-1)
+	assert.Equal(t, 2000, v)
+}
+
+type benchStore interface {
+	Put(key string, val int)
+	GetCurrent(key string) (int, bool)
+}
+
+type benchSyncMap struct {
+	m sync.Map
+}
+
+func (s *benchSyncMap) Put(key string, val int) {
+	s.m.Store(key, val)
+}
+
+func (s *benchSyncMap) GetCurrent(key string) (int, bool) {
+	v, ok := s.m.Load(key)
+	if !ok {
+		return 0, false
+	}
+	return v.(int), true //nolint:forcetypeassert
+}
+
+func BenchmarkStores(b *testing.B) {
+	const keysCount = 1024
+
+	keys := make([]string, keysCount)
+	for i := range keys {
+		keys[i] = "key" + strconv.Itoa(i)
+	}
+
+	stores := []struct {
+		name string
+		make func() benchStore
+	}{
+		{name: "SyncStore", make: func() benchStore { return &dot.SyncStore[string, int]{} }},
+		{name: "ShardedSyncStore", make: func() benchStore { return dot.NewShardedSyncStore[string, int](0, nil) }},
+		{name: "sync.Map", make: func() benchStore { return &benchSyncMap{} }},
+	}
+
+	for _, writePercent := range []int{0, 10, 50, 90} {
+		for _, st := range stores {
+			b.Run(fmt.Sprintf("writes=%d%%/%s", writePercent, st.name), func(b *testing.B) {
+				store := st.make()
+				for i, key := range keys {
+					store.Put(key, i)
+				}
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					i := 0
+					for pb.Next() {
+						key := keys[i%keysCount]
+						if i%100 < writePercent {
+							store.Put(key, i)
+						} else {
+							store.GetCurrent(key)
+						}
+						i++
+					}
+				})
+			})
+		}
+	}
+}
+
+func TestShardedSyncStore_Bulk(t *testing.T) {
+	t.Parallel()
+
+	s := dot.NewShardedSyncStore[int, string](4, nil)
+	s.PutAll(map[int]string{1: "one", 2: "two"})
+	s.PutSeq(func(yield func(int, string) bool) {
+		_ = yield(3, "three") && yield(4, "four")
+	})
+	assert.Equal(t, 4, s.Len())
+
+	assert.Equal(t, map[int]string{1: "one", 4: "four"}, s.GetMany(1, 4, 5))
+	assert.Equal(t, 2, s.DeleteMany(1, 2, 5))
+	assert.Equal(t, map[int]string{3: "three", 4: "four"}, s.ToMap())
+
+	s.Clear()
+	assert.Equal(t, 0, s.Len())
+}
@@ -29,3 +29,222 @@ func SliceToSliceError[FROM, TO any](source []FROM, converter func(FROM) (TO, er
 
 	return result, nil
 }
+
+// Filter - returns new slice of values matched by keep
+func Filter[T any](source []T, keep func(T) bool) []T {
+	if source == nil {
+		return nil
+	}
+
+	result := make([]T, 0, len(source))
+	for i := range source {
+		if keep(source[i]) {
+			result = append(result, source[i])
+		}
+	}
+
+	return result
+}
+
+// FilterMap - converts values to new type slice, skipping values for which converter returns false
+func FilterMap[FROM, TO any](source []FROM, converter func(FROM) (TO, bool)) []TO {
+	if source == nil {
+		return nil
+	}
+
+	result := make([]TO, 0, len(source))
+	for i := range source {
+		if val, ok := converter(source[i]); ok {
+			result = append(result, val)
+		}
+	}
+
+	return result
+}
+
+// Fold - accumulates values into initial by folder, from the first value to the last
+func Fold[T, ACC any](source []T, initial ACC, folder func(acc ACC, val T) ACC) ACC {
+	acc := initial
+	for i := range source {
+		acc = folder(acc, source[i])
+	}
+
+	return acc
+}
+
+// Reduce - accumulates values into the first value by reducer, false for empty slice
+func Reduce[T any](source []T, reducer func(acc T, val T) T) (result T, ok bool) {
+	if len(source) == 0 {
+		return result, false
+	}
+
+	return Fold(source[1:], source[0], reducer), true
+}
+
+// GroupBy - groups values by key, keeping order of values inside groups
+func GroupBy[T any, K comparable](source []T, key func(T) K) map[K][]T {
+	if source == nil {
+		return nil
+	}
+
+	result := make(map[K][]T)
+	for i := range source {
+		k := key(source[i])
+		result[k] = append(result[k], source[i])
+	}
+
+	return result
+}
+
+// KeyBy - makes map of values by key, the last value wins for duplicate keys
+func KeyBy[T any, K comparable](source []T, key func(T) K) map[K]T {
+	if source == nil {
+		return nil
+	}
+
+	result := make(map[K]T, len(source))
+	for i := range source {
+		result[key(source[i])] = source[i]
+	}
+
+	return result
+}
+
+// Partition - splits values to matched and not matched by predicate
+func Partition[T any](source []T, predicate func(T) bool) (matched, rest []T) {
+	if source == nil {
+		return nil, nil
+	}
+
+	matched, rest = make([]T, 0, len(source)), make([]T, 0, len(source))
+	for i := range source {
+		if predicate(source[i]) {
+			matched = append(matched, source[i])
+		} else {
+			rest = append(rest, source[i])
+		}
+	}
+
+	return matched, rest
+}
+
+// Chunk - splits slice into consecutive subslices of size values, the last one may be shorter.
+// Subslices share memory with source, but appending to them does not affect source. Panics if size < 1.
+func Chunk[T any](source []T, size int) [][]T {
+	if size < 1 {
+		panic("dot.Chunk: size must be positive")
+	}
+	if source == nil {
+		return nil
+	}
+
+	count := len(source) / size
+	if len(source)%size != 0 {
+		count++
+	}
+	result := make([][]T, 0, count)
+	for start := 0; start < len(source); start += size {
+		end := start + min(size, len(source)-start)
+		result = append(result, source[start:end:end])
+	}
+
+	return result
+}
+
+// Window - returns all sliding windows of size consecutive values.
+// Windows share memory with source, but appending to them does not affect source. Panics if size < 1.
+func Window[T any](source []T, size int) [][]T {
+	if size < 1 {
+		panic("dot.Window: size must be positive")
+	}
+	if source == nil {
+		return nil
+	}
+
+	result := make([][]T, 0, max(len(source)-size+1, 0))
+	for start := 0; start+size <= len(source); start++ {
+		result = append(result, source[start:start+size:start+size])
+	}
+
+	return result
+}
+
+// UniqBy - returns values with unique keys, keeping the first value for every key
+func UniqBy[T any, K comparable](source []T, key func(T) K) []T {
+	if source == nil {
+		return nil
+	}
+
+	seen := make(Set[K], len(source))
+	result := make([]T, 0, len(source))
+	for i := range source {
+		k := key(source[i])
+		if !seen.Contains(k) {
+			seen.Add(k)
+			result = append(result, source[i])
+		}
+	}
+
+	return result
+}
+
+// Flatten - concatenates subslices
+func Flatten[T any](source [][]T) []T {
+	if source == nil {
+		return nil
+	}
+
+	length := 0
+	for i := range source {
+		length += len(source[i])
+	}
+
+	result := make([]T, 0, length)
+	for i := range source {
+		result = append(result, source[i]...)
+	}
+
+	return result
+}
+
+// Zip - makes pairs of values with the same index, the longer slice is truncated
+func Zip[A, B any](first []A, second []B) []Pair[A, B] {
+	if first == nil || second == nil {
+		return nil
+	}
+
+	result := make([]Pair[A, B], min(len(first), len(second)))
+	for i := range result {
+		result[i] = Pair[A, B]{First: first[i], Second: second[i]}
+	}
+
+	return result
+}
+
+// Unzip - splits pairs to slices of the first and the second values
+func Unzip[A, B any](pairs []Pair[A, B]) (first []A, second []B) {
+	if pairs == nil {
+		return nil, nil
+	}
+
+	first, second = make([]A, len(pairs)), make([]B, len(pairs))
+	for i := range pairs {
+		first[i], second[i] = pairs[i].First, pairs[i].Second
+	}
+
+	return first, second
+}
+
+// ToSet - makes Set of values
+func ToSet[T comparable](source []T) Set[T] {
+	if source == nil {
+		return nil
+	}
+
+	result := make(Set[T], len(source))
+	for i := range source {
+		result.Add(source[i])
+	}
+
+	return result
+}
@@ -0,0 +1,267 @@
+package dot
+
+import (
+	"errors"
+	"slices"
+)
+
+// ErrPatchMismatch - patch does not match the slice it is applied to
+var ErrPatchMismatch = errors.New("patch does not match slice")
+
+// DiffOpKind - kind of slice edit operation
+type DiffOpKind int
+
+const (
+	DiffKeep   DiffOpKind = iota // value is present in both slices
+	DiffDelete                   // value is present only in the old slice
+	DiffInsert                   // value is present only in the new slice
+)
+
+func (k DiffOpKind) String() string {
+	switch k {
+	case DiffKeep:
+		return "keep"
+	case DiffDelete:
+		return "delete"
+	case DiffInsert:
+		return "insert"
+	default:
+		return "unknown"
+	}
+}
+
+// DiffOp - slice edit operation. Val is the value of the new slice for keep and insert, of the old one for delete.
+type DiffOp[T any] struct {
+	Kind DiffOpKind
+	Val  T
+}
+
+// Patch - sequence of edit operations turning the old slice into the new one
+type Patch[T any] []DiffOp[T]
+
+// HasChanges - reports whether patch contains inserts or deletes
+func (p Patch[T]) HasChanges() bool {
+	return slices.ContainsFunc(p, func(op DiffOp[T]) bool { return op.Kind != DiffKeep })
+}
+
+// Apply - makes the new slice from the old one. Only lengths are validated: returns ErrPatchMismatch
+// if length of the old slice does not match count of keep and delete operations.
+// Use ApplyFunc or ApplyPatch to validate values as well.
+func (p Patch[T]) Apply(oldSlice []T) ([]T, error) {
+	return p.apply(oldSlice, nil)
+}
+
+// ApplyFunc - makes the new slice from the old one, comparing values by eq. Returns ErrPatchMismatch
+// if the old slice value does not match the keep or delete operation value, or if lengths do not match.
+func (p Patch[T]) ApplyFunc(oldSlice []T, eq func(a, b T) bool) ([]T, error) {
+	return p.apply(oldSlice, eq)
+}
+
+// ApplyPatch - makes the new slice from the old one like Patch.ApplyFunc, comparing values by ==
+func ApplyPatch[T comparable](patch Patch[T], oldSlice []T) ([]T, error) {
+	return patch.apply(oldSlice, func(a, b T) bool { return a == b })
+}
+
+// apply makes the new slice, eq is nil if values must not be compared
+func (p Patch[T]) apply(oldSlice []T, eq func(a, b T) bool) ([]T, error) {
+	result := make([]T, 0, len(p))
+	pos := 0
+	for _, op := range p {
+		if op.Kind != DiffInsert {
+			if pos >= len(oldSlice) || (eq != nil && !eq(oldSlice[pos], op.Val)) {
+				return nil, ErrPatchMismatch
+			}
+			pos++
+		}
+		if op.Kind != DiffDelete {
+			result = append(result, op.Val)
+		}
+	}
+	if pos != len(oldSlice) {
+		return nil, ErrPatchMismatch
+	}
+
+	return result, nil
+}
+
+// DiffSlices - computes minimal patch turning oldSlice into newSlice by Myers algorithm
+func DiffSlices[T comparable](oldSlice, newSlice []T) Patch[T] {
+	return DiffSlicesFunc(oldSlice, newSlice, func(a, b T) bool { return a == b })
+}
+
+// DiffSlicesFunc - computes minimal patch turning oldSlice into newSlice by Myers algorithm, comparing values by eq.
+// Uses linear-space variant of the algorithm, so memory is O(len(oldSlice)+len(newSlice)).
+func DiffSlicesFunc[T any](oldSlice, newSlice []T, eq func(a, b T) bool) Patch[T] {
+	d := slicesDiffer[T]{
+		eq:    eq,
+		patch: make(Patch[T], 0, max(len(oldSlice), len(newSlice))),
+	}
+	d.diff(oldSlice, newSlice)
+
+	return d.patch
+}
+
+// slicesDiffer - linear-space Myers diff, see "An O(ND) Difference Algorithm and Its Variations", section 4b
+type slicesDiffer[T any] struct {
+	eq    func(a, b T) bool
+	patch Patch[T]
+}
+
+// diff appends to patch the shortest edit script turning a into b
+func (d *slicesDiffer[T]) diff(a, b []T) {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && d.eq(a[prefix], b[prefix]) {
+		prefix++
+	}
+	d.appendOps(DiffKeep, b[:prefix])
+	a, b = a[prefix:], b[prefix:]
+
+	suffix := 0
+	for suffix < len(a) && suffix < len(b) && d.eq(a[len(a)-1-suffix], b[len(b)-1-suffix]) {
+		suffix++
+	}
+	middleA, middleB := a[:len(a)-suffix], b[:len(b)-suffix]
+
+	switch {
+	case len(middleA) == 0:
+		d.appendOps(DiffInsert, middleB)
+	case len(middleB) == 0:
+		d.appendOps(DiffDelete, middleA)
+	default:
+		// without common prefix and suffix the script has at least 2 edits,
+		// so both parts split by the middle snake are smaller
+		x, y := d.middleSnake(middleA, middleB)
+		d.diff(middleA[:x], middleB[:y])
+		d.diff(middleA[x:], middleB[y:])
+	}
+
+	d.appendOps(DiffKeep, b[len(b)-suffix:])
+}
+
+// middleSnake returns point of the shortest edit script of not empty a and b,
+// found where forward and backward searches meet
+func (d *slicesDiffer[T]) middleSnake(a, b []T) (x, y int) {
+	n, m := len(a), len(b)
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	// furthest x of forward and backward paths for every diagonal k, indexed by offset+k
+	forward, backward := make([]int, 2*maxD+2), make([]int, 2*maxD+2)
+	for i := range forward {
+		forward[i], backward[i] = -1, -1
+	}
+	forward[offset+1], backward[offset+1] = 0, 0
+
+	delta := n - m
+	checkForward := delta%2 != 0 // paths meet on forward step for odd delta, on backward one for even
+	// diagonals leaving the edit graph are skipped
+	var fStart, fEnd, bStart, bEnd int
+
+	for step := 0; step < maxD; step++ {
+		for k := -step + fStart; k <= step-fEnd; k += 2 {
+			var x1 int
+			if k == -step || (k != step && forward[offset+k-1] < forward[offset+k+1]) {
+				x1 = forward[offset+k+1]
+			} else {
+				x1 = forward[offset+k-1] + 1
+			}
+			y1 := x1 - k
+			for x1 < n && y1 < m && d.eq(a[x1], b[y1]) {
+				x1++
+				y1++
+			}
+			forward[offset+k] = x1
+
+			switch {
+			case x1 > n:
+				fEnd += 2
+			case y1 > m:
+				fStart += 2
+			case checkForward:
+				if bk := offset + delta - k; bk >= 0 && bk < len(backward) && backward[bk] != -1 && x1 >= n-backward[bk] {
+					return x1, y1
+				}
+			}
+		}
+
+		for k := -step + bStart; k <= step-bEnd; k += 2 {
+			var x2 int
+			if k == -step || (k != step && backward[offset+k-1] < backward[offset+k+1]) {
+				x2 = backward[offset+k+1]
+			} else {
+				x2 = backward[offset+k-1] + 1
+			}
+			y2 := x2 - k
+			for x2 < n && y2 < m && d.eq(a[n-x2-1], b[m-y2-1]) {
+				x2++
+				y2++
+			}
+			backward[offset+k] = x2
+
+			switch {
+			case x2 > n:
+				bEnd += 2
+			case y2 > m:
+				bStart += 2
+			case !checkForward:
+				if fk := offset + delta - k; fk >= 0 && fk < len(forward) && forward[fk] != -1 {
+					x1 := forward[fk]
+					if x1 >= n-x2 {
+						return x1, x1 - (delta - k)
+					}
+				}
+			}
+		}
+	}
+
+	// unreachable for correct input, deleting all and inserting all is still a valid script
+	return n, 0
+}
+
+func (d *slicesDiffer[T]) appendOps(kind DiffOpKind, vals []T) {
+	for _, v := range vals {
+		d.patch = append(d.patch, DiffOp[T]{Kind: kind, Val: v})
+	}
+}
+
+// KeyedDiff - difference of slices of values identified by keys
+type KeyedDiff[T any] struct {
+	Added    []T          // values with keys present only in the new slice, in order of the new slice
+	Removed  []T          // values with keys present only in the old slice, in order of the old slice
+	Modified []Pair[T, T] // old and new values with the same key which are not equal, in order of the new slice
+}
+
+// IsEmpty - reports whether slices contain the same values by keys
+func (d KeyedDiff[T]) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// DiffBy - compares values of slices identified by key, ignoring their order.
+// Keys must be unique inside every slice.
+func DiffBy[T, K comparable](oldSlice, newSlice []T, key func(T) K) KeyedDiff[T] {
+	return DiffByFunc(oldSlice, newSlice, key, func(a, b T) bool { return a == b })
+}
+
+// DiffByFunc - compares values of slices identified by key by eq, ignoring their order.
+// Keys must be unique inside every slice.
+func DiffByFunc[T any, K comparable](oldSlice, newSlice []T, key func(T) K, eq func(a, b T) bool) KeyedDiff[T] {
+	oldByKey := KeyBy(oldSlice, key)
+	newByKey := KeyBy(newSlice, key)
+
+	var diff KeyedDiff[T]
+	for _, v := range oldSlice {
+		if _, found := newByKey[key(v)]; !found {
+			diff.Removed = append(diff.Removed, v)
+		}
+	}
+	for _, v := range newSlice {
+		oldVal, found := oldByKey[key(v)]
+		switch {
+		case !found:
+			diff.Added = append(diff.Added, v)
+		case !eq(oldVal, v):
+			diff.Modified = append(diff.Modified, Pair[T, T]{First: oldVal, Second: v})
+		}
+	}
+
+	return diff
+}
@@ -0,0 +1,188 @@
+package dot_test
+
+import (
+	"math/rand/v2"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirrorru/dot"
+)
+
+func patchString(patch dot.Patch[string]) string {
+	var sb strings.Builder
+	for _, op := range patch {
+		switch op.Kind {
+		case dot.DiffKeep:
+			sb.WriteString(" ")
+		case dot.DiffDelete:
+			sb.WriteString("-")
+		case dot.DiffInsert:
+			sb.WriteString("+")
+		}
+		sb.WriteString(op.Val)
+	}
+
+	return sb.String()
+}
+
+func TestDiffSlices(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		old, new string
+		expected string
+	}{
+		{name: "empty", old: "", new: "", expected: ""},
+		{name: "equal", old: "abc", new: "abc", expected: " a b c"},
+		{name: "insert all", old: "", new: "ab", expected: "+a+b"},
+		{name: "delete all", old: "ab", new: "", expected: "-a-b"},
+		{name: "replace", old: "abc", new: "axc", expected: " a-b+x c"},
+		{name: "myers", old: "abcabba", new: "cbabac", expected: "-a+c b-c a b-b a+c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			oldSlice, newSlice := strings.Split(tt.old, ""), strings.Split(tt.new, "")
+			patch := dot.DiffSlices(oldSlice, newSlice)
+			assert.Equal(t, tt.expected, patchString(patch))
+			assert.Equal(t, tt.old != tt.new, patch.HasChanges())
+
+			applied, err := dot.ApplyPatch(patch, oldSlice)
+			require.NoError(t, err)
+			assert.Equal(t, newSlice, applied)
+		})
+	}
+}
+
+func TestDiffSlices_Random(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewPCG(1, 2))
+	randomSlice := func() []int {
+		s := make([]int, rnd.IntN(30))
+		for i := range s {
+			s[i] = rnd.IntN(5)
+		}
+		return s
+	}
+
+	for range 500 {
+		oldSlice, newSlice := randomSlice(), randomSlice()
+		patch := dot.DiffSlices(oldSlice, newSlice)
+
+		applied, err := patch.Apply(oldSlice)
+		require.NoError(t, err)
+		assert.Equal(t, newSlice, applied)
+
+		keeps := 0
+		for _, op := range patch {
+			if op.Kind == dot.DiffKeep {
+				keeps++
+			}
+		}
+		require.Equal(t, lcsLength(oldSlice, newSlice), keeps, "patch must be minimal")
+	}
+}
+
+// TestDiffSlices_LargeDisjointMemory is not parallel, so other tests do not affect allocation counters
+func TestDiffSlices_LargeDisjointMemory(t *testing.T) {
+	const size = 3000
+	oldSlice, newSlice := make([]int, size), make([]int, size)
+	for i := range size {
+		oldSlice[i], newSlice[i] = i, size+i
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	patch := dot.DiffSlices(oldSlice, newSlice)
+	runtime.ReadMemStats(&after)
+
+	require.Len(t, patch, 2*size)
+	applied, err := patch.Apply(oldSlice)
+	require.NoError(t, err)
+	assert.Equal(t, newSlice, applied)
+
+	// quadratic memory takes hundreds of megabytes here
+	assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(4<<20))
+}
+
+func lcsLength(a, b []int) int {
+	dp := make([][]int, len(a)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(b)+1)
+	}
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				dp[i+1][j+1] = dp[i][j] + 1
+			} else {
+				dp[i+1][j+1] = max(dp[i][j+1], dp[i+1][j])
+			}
+		}
+	}
+
+	return dp[len(a)][len(b)]
+}
+
+func TestDiffSlicesFunc(t *testing.T) {
+	t.Parallel()
+
+	patch := dot.DiffSlicesFunc([]string{"A", "b"}, []string{"a", "B", "c"}, strings.EqualFold)
+	assert.Equal(t, " a B+c", patchString(patch))
+
+	applied, err := patch.ApplyFunc([]string{"A", "b"}, strings.EqualFold)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "B", "c"}, applied)
+	_, err = patch.ApplyFunc([]string{"A", "x"}, strings.EqualFold)
+	require.ErrorIs(t, err, dot.ErrPatchMismatch)
+}
+
+func TestPatch_ApplyMismatch(t *testing.T) {
+	t.Parallel()
+
+	patch := dot.DiffSlices([]int{1, 2, 3}, []int{1, 3})
+	_, err := patch.Apply([]int{1, 2})
+	require.ErrorIs(t, err, dot.ErrPatchMismatch)
+	_, err = patch.Apply([]int{1, 2, 3, 4})
+	require.ErrorIs(t, err, dot.ErrPatchMismatch)
+
+	// same length, other values: only ApplyPatch notices
+	applied, err := patch.Apply([]int{7, 8, 9})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, applied)
+	_, err = dot.ApplyPatch(patch, []int{7, 8, 9})
+	require.ErrorIs(t, err, dot.ErrPatchMismatch)
+	_, err = dot.ApplyPatch(patch, []int{1, 9, 3})
+	require.ErrorIs(t, err, dot.ErrPatchMismatch)
+	assert.Equal(t, "delete", dot.DiffDelete.String())
+}
+
+type diffUser struct {
+	ID   int
+	Name string
+}
+
+func TestDiffBy(t *testing.T) {
+	t.Parallel()
+
+	oldSlice := []diffUser{{1, "ann"}, {2, "bob"}, {3, "cid"}}
+	newSlice := []diffUser{{4, "dan"}, {3, "cid"}, {1, "anna"}}
+	id := func(u diffUser) int { return u.ID }
+
+	diff := dot.DiffBy(oldSlice, newSlice, id)
+	assert.Equal(t, []diffUser{{4, "dan"}}, diff.Added)
+	assert.Equal(t, []diffUser{{2, "bob"}}, diff.Removed)
+	assert.Equal(t, []dot.Pair[diffUser, diffUser]{{First: diffUser{1, "ann"}, Second: diffUser{1, "anna"}}}, diff.Modified)
+	assert.False(t, diff.IsEmpty())
+
+	assert.True(t, dot.DiffBy(oldSlice, []diffUser{{3, "cid"}, {2, "bob"}, {1, "ann"}}, id).IsEmpty())
+
+	diff = dot.DiffByFunc(oldSlice, newSlice, id, func(_, _ diffUser) bool { return true })
+	assert.Empty(t, diff.Modified)
+}
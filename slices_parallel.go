@@ -0,0 +1,106 @@
+package dot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// ParallelErrorMode - error handling mode of ParallelSliceToSliceError
+type ParallelErrorMode int
+
+const (
+	StopOnFirstError ParallelErrorMode = iota // cancels remaining work and returns the first error
+	CollectAllErrors                          // converts all values and returns all errors joined
+)
+
+// ParallelSliceToSlice - converts slice to new type slice by up to limit goroutines, preserving order.
+// Non-positive limit means GOMAXPROCS. Returns error if ctx is done before all values are converted
+// or if converter panics, the panic is returned as PanicError.
+func ParallelSliceToSlice[FROM, TO any](
+	ctx context.Context, source []FROM, limit int, converter func(FROM) TO,
+) ([]TO, error) {
+	return ParallelSliceToSliceError(ctx, source, limit, StopOnFirstError,
+		func(_ context.Context, src FROM) (TO, error) {
+			return converter(src), nil
+		})
+}
+
+// ParallelSliceToSliceError - converts slice to new type slice by up to limit goroutines, preserving order.
+// Non-positive limit means GOMAXPROCS. Panics of converter are returned as PanicError.
+// With StopOnFirstError mode the first error cancels ctx passed to converter and stops taking new values.
+// With CollectAllErrors mode all values are converted and errors are joined, every error is prefixed by value index.
+// Result is nil on any error.
+func ParallelSliceToSliceError[FROM, TO any](
+	ctx context.Context, source []FROM, limit int, mode ParallelErrorMode,
+	converter func(ctx context.Context, src FROM) (TO, error),
+) ([]TO, error) {
+	if source == nil {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	if limit <= 0 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+
+	result := make([]TO, len(source))
+	errs := make([]error, len(source))
+	var next atomic.Int64
+	var wg sync.WaitGroup
+
+	for range min(limit, len(source)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if ctx.Err() != nil {
+					return
+				}
+				idx := int(next.Add(1) - 1)
+				if idx >= len(source) {
+					return
+				}
+
+				result[idx], errs[idx] = CallRecover(func() (TO, error) {
+					return converter(ctx, source[idx])
+				})
+				if errs[idx] != nil && mode == StopOnFirstError {
+					cancel(errs[idx])
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// ctx was done before all values were taken
+	incomplete := int(next.Load()) < len(source)
+
+	if mode == StopOnFirstError {
+		if incomplete || slices.ContainsFunc(errs, func(err error) bool { return err != nil }) {
+			return nil, context.Cause(ctx)
+		}
+		return result, nil
+	}
+
+	var joined []error
+	for idx, err := range errs {
+		if err != nil {
+			joined = append(joined, fmt.Errorf("value %d: %w", idx, err))
+		}
+	}
+	if incomplete {
+		joined = append(joined, context.Cause(ctx))
+	}
+	if len(joined) > 0 {
+		return nil, errors.Join(joined...)
+	}
+
+	return result, nil
+}
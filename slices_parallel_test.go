@@ -0,0 +1,125 @@
+package dot_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirrorru/dot"
+)
+
+func TestParallelSliceToSlice(t *testing.T) {
+	t.Parallel()
+
+	source := make([]int, 100)
+	for i := range source {
+		source[i] = i
+	}
+
+	var active, maxActive atomic.Int32
+	result, err := dot.ParallelSliceToSlice(t.Context(), source, 4, func(v int) string {
+		cur := active.Add(1)
+		defer active.Add(-1)
+		for {
+			prev := maxActive.Load()
+			if cur <= prev || maxActive.CompareAndSwap(prev, cur) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		return strconv.Itoa(v)
+	})
+	require.NoError(t, err)
+	require.Len(t, result, len(source))
+	for i, v := range result {
+		assert.Equal(t, strconv.Itoa(i), v)
+	}
+	assert.LessOrEqual(t, maxActive.Load(), int32(4))
+
+	result, err = dot.ParallelSliceToSlice(t.Context(), nil, 0, strconv.Itoa)
+	require.NoError(t, err)
+	assert.Nil(t, result)
+
+	result, err = dot.ParallelSliceToSlice(t.Context(), []int{}, 0, strconv.Itoa)
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, result)
+}
+
+func TestParallelSliceToSlice_Cancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	result, err := dot.ParallelSliceToSlice(ctx, []int{1, 2, 3}, 2, strconv.Itoa)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, result)
+}
+
+func TestParallelSliceToSliceError_StopOnFirstError(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	source := make([]int, 1000)
+	result, err := dot.ParallelSliceToSliceError(t.Context(), source, 2, dot.StopOnFirstError,
+		func(ctx context.Context, _ int) (int, error) {
+			if calls.Add(1) == 3 {
+				return 0, assert.AnError
+			}
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(time.Millisecond):
+				return 1, nil
+			}
+		})
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, result)
+	assert.Less(t, calls.Load(), int32(len(source)))
+}
+
+func TestParallelSliceToSliceError_CollectAllErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	source := []int{0, 1, 2, 3, 4, 5}
+	result, err := dot.ParallelSliceToSliceError(t.Context(), source, 3, dot.CollectAllErrors,
+		func(_ context.Context, v int) (int, error) {
+			calls.Add(1)
+			if v%2 == 1 {
+				return 0, errors.New("odd " + strconv.Itoa(v))
+			}
+			return v, nil
+		})
+	assert.Nil(t, result)
+	assert.Equal(t, int32(len(source)), calls.Load())
+	require.Error(t, err)
+	assert.Equal(t, "value 1: odd 1\nvalue 3: odd 3\nvalue 5: odd 5", err.Error())
+
+	result, err = dot.ParallelSliceToSliceError(t.Context(), source, 0, dot.CollectAllErrors,
+		func(_ context.Context, v int) (int, error) { return v * 2, nil })
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 2, 4, 6, 8, 10}, result)
+}
+
+func TestParallelSliceToSliceError_Panic(t *testing.T) {
+	t.Parallel()
+
+	for _, mode := range []dot.ParallelErrorMode{dot.StopOnFirstError, dot.CollectAllErrors} {
+		_, err := dot.ParallelSliceToSliceError(t.Context(), []int{1, 2, 3}, 2, mode,
+			func(_ context.Context, v int) (int, error) {
+				if v == 2 {
+					panic("boom")
+				}
+				return v, nil
+			})
+		var panicErr *dot.PanicError
+		require.ErrorAs(t, err, &panicErr)
+		assert.Equal(t, "boom", panicErr.Value)
+	}
+}
@@ -2,7 +2,9 @@ package dot_test
 
 import (
 	"errors"
+	"math"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/mirrorru/dot"
@@ -64,3 +66,150 @@ func TestSliceToSliceError(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	isEven := func(v int) bool { return v%2 == 0 }
+	assert.Nil(t, dot.Filter([]int(nil), isEven))
+	assert.Equal(t, []int{}, dot.Filter([]int{1, 3}, isEven))
+	assert.Equal(t, []int{2, 4}, dot.Filter([]int{1, 2, 3, 4}, isEven))
+}
+
+func TestFilterMap(t *testing.T) {
+	t.Parallel()
+
+	parse := func(s string) (int, bool) {
+		v, err := strconv.Atoi(s)
+		return v, err == nil
+	}
+	assert.Nil(t, dot.FilterMap([]string(nil), parse))
+	assert.Equal(t, []int{1, 3}, dot.FilterMap([]string{"1", "x", "3"}, parse))
+}
+
+func TestFoldReduce(t *testing.T) {
+	t.Parallel()
+
+	sum := func(acc, v int) int { return acc + v }
+	assert.Equal(t, 10, dot.Fold([]int{1, 2, 3, 4}, 0, sum))
+	assert.Equal(t, "abc", dot.Fold([]rune{'a', 'b', 'c'}, "", func(acc string, r rune) string {
+		return acc + string(r)
+	}))
+
+	res, ok := dot.Reduce([]int{1, 2, 3}, sum)
+	assert.True(t, ok)
+	assert.Equal(t, 6, res)
+	_, ok = dot.Reduce([]int{}, sum)
+	assert.False(t, ok)
+}
+
+func TestGroupByKeyBy(t *testing.T) {
+	t.Parallel()
+
+	words := []string{"apple", "avocado", "banana", "blueberry", "cherry"}
+	firstLetter := func(s string) byte { return s[0] }
+
+	assert.Nil(t, dot.GroupBy([]string(nil), firstLetter))
+	assert.Equal(t, map[byte][]string{
+		'a': {"apple", "avocado"},
+		'b': {"banana", "blueberry"},
+		'c': {"cherry"},
+	}, dot.GroupBy(words, firstLetter))
+
+	assert.Nil(t, dot.KeyBy([]string(nil), firstLetter))
+	assert.Equal(t, map[byte]string{'a': "avocado", 'b': "blueberry", 'c': "cherry"}, dot.KeyBy(words, firstLetter))
+}
+
+func TestPartition(t *testing.T) {
+	t.Parallel()
+
+	matched, rest := dot.Partition([]int(nil), func(int) bool { return true })
+	assert.Nil(t, matched)
+	assert.Nil(t, rest)
+
+	matched, rest = dot.Partition([]int{1, 2, 3, 4, 5}, func(v int) bool { return v > 2 })
+	assert.Equal(t, []int{3, 4, 5}, matched)
+	assert.Equal(t, []int{1, 2}, rest)
+}
+
+func TestChunk(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		source []int
+		size   int
+		expect [][]int
+	}{
+		{name: "nil", source: nil, size: 2, expect: nil},
+		{name: "empty", source: []int{}, size: 2, expect: [][]int{}},
+		{name: "even", source: []int{1, 2, 3, 4}, size: 2, expect: [][]int{{1, 2}, {3, 4}}},
+		{name: "odd", source: []int{1, 2, 3}, size: 2, expect: [][]int{{1, 2}, {3}}},
+		{name: "big size", source: []int{1, 2}, size: 5, expect: [][]int{{1, 2}}},
+		{name: "max size", source: []int{1, 2}, size: math.MaxInt, expect: [][]int{{1, 2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expect, dot.Chunk(tt.source, tt.size))
+		})
+	}
+
+	source := []int{1, 2, 3, 4}
+	chunks := dot.Chunk(source, 2)
+	_ = append(chunks[0], 100)
+	assert.Equal(t, []int{1, 2, 3, 4}, source, "appending to chunk must not change source")
+
+	assert.Panics(t, func() { dot.Chunk(source, 0) })
+}
+
+func TestWindow(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, dot.Window([]int(nil), 2))
+	assert.Equal(t, [][]int{}, dot.Window([]int{1}, 2))
+	assert.Equal(t, [][]int{{1, 2}, {2, 3}, {3, 4}}, dot.Window([]int{1, 2, 3, 4}, 2))
+	assert.Equal(t, [][]int{}, dot.Window([]int{1, 2}, math.MaxInt))
+	assert.Panics(t, func() { dot.Window([]int{1}, 0) })
+}
+
+func TestUniqBy(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, dot.UniqBy([]string(nil), strings.ToLower))
+	assert.Equal(t, []string{"Go", "rust", "C"}, dot.UniqBy([]string{"Go", "rust", "go", "C", "RUST"}, strings.ToLower))
+}
+
+func TestFlatten(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, dot.Flatten([][]int(nil)))
+	assert.Equal(t, []int{1, 2, 3, 4}, dot.Flatten([][]int{{1}, nil, {2, 3}, {4}}))
+}
+
+func TestZipUnzip(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, dot.Zip([]int(nil), []string{"a"}))
+	pairs := dot.Zip([]int{1, 2, 3}, []string{"a", "b"})
+	assert.Equal(t, []dot.Pair[int, string]{{First: 1, Second: "a"}, {First: 2, Second: "b"}}, pairs)
+
+	first, second := dot.Unzip(pairs)
+	assert.Equal(t, []int{1, 2}, first)
+	assert.Equal(t, []string{"a", "b"}, second)
+
+	first, second = dot.Unzip([]dot.Pair[int, string](nil))
+	assert.Nil(t, first)
+	assert.Nil(t, second)
+}
+
+func TestToSet(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, dot.ToSet([]int(nil)))
+	set := dot.ToSet([]int{1, 2, 2})
+	assert.Len(t, set, 2)
+	assert.True(t, set.Contains(1))
+	assert.True(t, set.Contains(2))
+}
@@ -0,0 +1,139 @@
+package dot
+
+import (
+	"iter"
+	"slices"
+	"sync"
+)
+
+// Stack - LIFO stack, not concurrency-safe, see SyncStack. Zero value is ready to use.
+type Stack[T any] struct {
+	values []T
+}
+
+func NewStack[T any](capacity int) *Stack[T] {
+	return &Stack[T]{values: make([]T, 0, capacity)}
+}
+
+// Push - puts values on top, the last one becomes the top
+func (s *Stack[T]) Push(vals ...T) {
+	s.values = append(s.values, vals...)
+}
+
+// Pop - removes and returns the top value, false for empty stack
+func (s *Stack[T]) Pop() (val T, ok bool) {
+	if len(s.values) == 0 {
+		return val, false
+	}
+
+	last := len(s.values) - 1
+	val = s.values[last]
+	var empty T
+	s.values[last] = empty
+	s.values = s.values[:last]
+
+	return val, true
+}
+
+// Peek - returns the top value without removing, false for empty stack
+func (s *Stack[T]) Peek() (val T, ok bool) {
+	if len(s.values) == 0 {
+		return val, false
+	}
+
+	return s.values[len(s.values)-1], true
+}
+
+func (s *Stack[T]) Len() int {
+	return len(s.values)
+}
+
+func (s *Stack[T]) Clear() {
+	clear(s.values)
+	s.values = s.values[:0]
+}
+
+// Values - returns copy of values from the bottom to the top
+func (s *Stack[T]) Values() []T {
+	return slices.Clone(s.values)
+}
+
+// Drain - pops values from the top until the stack is empty
+func (s *Stack[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			val, ok := s.Pop()
+			if !ok || !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// SyncStack - concurrency-safe Stack. It must not be copied after first use.
+type SyncStack[T any] struct {
+	mx    sync.Mutex
+	stack Stack[T]
+}
+
+func NewSyncStack[T any](capacity int) *SyncStack[T] {
+	return &SyncStack[T]{stack: Stack[T]{values: make([]T, 0, capacity)}}
+}
+
+// Push - puts values on top, the last one becomes the top
+func (s *SyncStack[T]) Push(vals ...T) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.stack.Push(vals...)
+}
+
+// Pop - removes and returns the top value, false for empty stack
+func (s *SyncStack[T]) Pop() (val T, ok bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return s.stack.Pop()
+}
+
+// Peek - returns the top value without removing, false for empty stack
+func (s *SyncStack[T]) Peek() (val T, ok bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return s.stack.Peek()
+}
+
+func (s *SyncStack[T]) Len() int {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return s.stack.Len()
+}
+
+func (s *SyncStack[T]) Clear() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.stack.Clear()
+}
+
+// Values - returns copy of values from the bottom to the top
+func (s *SyncStack[T]) Values() []T {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return s.stack.Values()
+}
+
+// Drain - pops values from the top until the stack is empty, the lock is held only while popping
+func (s *SyncStack[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			val, ok := s.Pop()
+			if !ok || !yield(val) {
+				return
+			}
+		}
+	}
+}
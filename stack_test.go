@@ -0,0 +1,74 @@
+package dot_test
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mirrorru/dot"
+)
+
+func TestStack(t *testing.T) {
+	t.Parallel()
+
+	var s dot.Stack[int]
+	_, ok := s.Pop()
+	assert.False(t, ok)
+	_, ok = s.Peek()
+	assert.False(t, ok)
+
+	s.Push(1, 2)
+	s.Push(3)
+	assert.Equal(t, 3, s.Len())
+	assert.Equal(t, []int{1, 2, 3}, s.Values())
+
+	val, ok := s.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 3, val)
+	val, ok = s.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 3, val)
+
+	s.Push(4)
+	assert.Equal(t, []int{4, 2, 1}, slices.Collect(s.Drain()))
+	assert.Equal(t, 0, s.Len())
+
+	s2 := dot.NewStack[string](4)
+	s2.Push("a", "b")
+	s2.Clear()
+	assert.Empty(t, s2.Values())
+}
+
+func TestSyncStack(t *testing.T) {
+	t.Parallel()
+
+	s := dot.NewSyncStack[int](0)
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				s.Push(i*100 + j)
+				if j%2 == 0 {
+					s.Pop()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 500, s.Len())
+
+	val, ok := s.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, s.Values()[499], val)
+	assert.Len(t, slices.Collect(s.Drain()), 500)
+	_, ok = s.Pop()
+	assert.False(t, ok)
+
+	s.Push(1)
+	s.Clear()
+	assert.Equal(t, 0, s.Len())
+}
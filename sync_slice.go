@@ -1,13 +1,18 @@
 package dot
 
 import (
+	"encoding/json"
+	"fmt"
 	"iter"
+	"slices"
 	"sync"
 )
 
+// SyncSlice - concurrency-safe slice. It must not be copied after first use.
 type SyncSlice[T any] struct {
 	mx     sync.Mutex
 	values []T
+	order  func(a, b T) int // comparator of sorted-insert mode, see KeepSorted
 }
 
 func NewSyncSlice[T any](length, capacity int) *SyncSlice[T] {
@@ -21,6 +26,14 @@ func MakeSyncSlice[T any](length, capacity int) SyncSlice[T] {
 	}
 }
 
+// NewSortedSyncSlice - makes empty slice in sorted-insert mode, see KeepSorted
+func NewSortedSyncSlice[T any](capacity int, cmp func(a, b T) int) *SyncSlice[T] {
+	s := NewSyncSlice[T](0, capacity)
+	s.order = cmp
+
+	return s
+}
+
 // InitSize - set internal slice length and capacity
 // Depricated: use MakeSyncSlice
 func (s *SyncSlice[T]) InitSize(length, capacity int) {
@@ -35,11 +48,12 @@ func (s *SyncSlice[T]) Len() int {
 	return len(s.values)
 }
 
+// Append - appends value to the end, or inserts it into ordered position in sorted-insert mode
 func (s *SyncSlice[T]) Append(val T) {
 	s.mx.Lock()
 	defer s.mx.Unlock()
 
-	s.values = append(s.values, val)
+	s.appendVal(val)
 }
 
 func (s *SyncSlice[T]) Get(index int) (val T) {
@@ -56,6 +70,233 @@ func (s *SyncSlice[T]) Set(index int, val T) {
 	s.values[index] = val
 }
 
+// AppendAll - appends all values under a single lock, see Append
+func (s *SyncSlice[T]) AppendAll(vals ...T) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if s.order == nil {
+		s.values = append(s.values, vals...)
+		return
+	}
+	for _, val := range vals {
+		s.appendVal(val)
+	}
+}
+
+// TryGet - returns value by index, false for index out of range
+func (s *SyncSlice[T]) TryGet(index int) (val T, ok bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if !s.inRange(index) {
+		return val, false
+	}
+
+	return s.values[index], true
+}
+
+// Insert - inserts values at index, false for index out of [0, Len()]
+func (s *SyncSlice[T]) Insert(index int, vals ...T) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if index < 0 || index > len(s.values) {
+		return false
+	}
+	s.values = slices.Insert(s.values, index, vals...)
+
+	return true
+}
+
+// RemoveAt - removes and returns value by index, false for index out of range
+func (s *SyncSlice[T]) RemoveAt(index int) (val T, ok bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if !s.inRange(index) {
+		return val, false
+	}
+	val = s.values[index]
+	s.values = slices.Delete(s.values, index, index+1)
+
+	return val, true
+}
+
+// Pop - removes and returns the last value, false for empty slice
+func (s *SyncSlice[T]) Pop() (val T, ok bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if len(s.values) == 0 {
+		return val, false
+	}
+	last := len(s.values) - 1
+	val = s.values[last]
+	s.values = slices.Delete(s.values, last, last+1)
+
+	return val, true
+}
+
+// Update - replaces value by index with result of updater, false for index out of range.
+// updater is called under the lock and must not access the slice.
+func (s *SyncSlice[T]) Update(index int, updater func(val T) T) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if !s.inRange(index) {
+		return false
+	}
+	s.values[index] = updater(s.values[index])
+
+	return true
+}
+
+// Swap - swaps values by indexes, false for any index out of range
+func (s *SyncSlice[T]) Swap(i, j int) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if !s.inRange(i) || !s.inRange(j) {
+		return false
+	}
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+
+	return true
+}
+
+// Truncate - shrinks slice to specified length, does nothing if length is not less than Len()
+func (s *SyncSlice[T]) Truncate(length int) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if length >= 0 && length < len(s.values) {
+		s.values = slices.Delete(s.values, length, len(s.values))
+	}
+}
+
+// Clear - removes all values, keeping capacity
+func (s *SyncSlice[T]) Clear() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	clear(s.values)
+	s.values = s.values[:0]
+}
+
+// DrainAll - returns all values and resets the slice
+func (s *SyncSlice[T]) DrainAll() []T {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	values := s.values
+	s.values = nil
+
+	return values
+}
+
+// Filter - keeps only values matched by keep and returns count of removed values.
+// keep is called under the lock and must not access the slice.
+func (s *SyncSlice[T]) Filter(keep func(val T) bool) (removed int) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	length := len(s.values)
+	s.values = slices.DeleteFunc(s.values, func(val T) bool {
+		return !keep(val)
+	})
+
+	return length - len(s.values)
+}
+
+// KeepSorted - sorts values by cmp and turns on sorted-insert mode:
+// Append and AppendAll insert values after all values not greater than them.
+// Set, Insert, Update, Swap, Reverse and SortFunc keep working by index and may break the order.
+func (s *SyncSlice[T]) KeepSorted(cmp func(a, b T) int) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	slices.SortStableFunc(s.values, cmp)
+	s.order = cmp
+}
+
+// SortFunc - sorts values by cmp
+func (s *SyncSlice[T]) SortFunc(cmp func(a, b T) int) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	slices.SortFunc(s.values, cmp)
+}
+
+// SortStableFunc - sorts values by cmp keeping the order of equal values
+func (s *SyncSlice[T]) SortStableFunc(cmp func(a, b T) int) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	slices.SortStableFunc(s.values, cmp)
+}
+
+// BinarySearchFunc - searches target in the slice sorted by cmp, see slices.BinarySearchFunc
+func (s *SyncSlice[T]) BinarySearchFunc(target T, cmp func(a, b T) int) (index int, found bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return slices.BinarySearchFunc(s.values, target, cmp)
+}
+
+// IndexFunc - returns index of the first value matched by f, -1 if none
+func (s *SyncSlice[T]) IndexFunc(f func(val T) bool) int {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return slices.IndexFunc(s.values, f)
+}
+
+// ContainsFunc - reports whether any value is matched by f
+func (s *SyncSlice[T]) ContainsFunc(f func(val T) bool) bool {
+	return s.IndexFunc(f) >= 0
+}
+
+// Reverse - reverses the order of values
+func (s *SyncSlice[T]) Reverse() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	slices.Reverse(s.values)
+}
+
+// CompactFunc - replaces runs of values equal by eq with a single value
+func (s *SyncSlice[T]) CompactFunc(eq func(a, b T) bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.values = slices.CompactFunc(s.values, eq)
+}
+
+// CompactSyncSlice - replaces runs of equal values of SyncSlice with a single value
+func CompactSyncSlice[T comparable](s *SyncSlice[T]) {
+	s.CompactFunc(func(a, b T) bool {
+		return a == b
+	})
+}
+
+func (s *SyncSlice[T]) appendVal(val T) {
+	if s.order == nil {
+		s.values = append(s.values, val)
+		return
+	}
+
+	// position after all values not greater than val
+	index, _ := slices.BinarySearchFunc(s.values, val, func(elem, target T) int {
+		return Iif(s.order(elem, target) <= 0, -1, 1)
+	})
+	s.values = slices.Insert(s.values, index, val)
+}
+
+func (s *SyncSlice[T]) inRange(index int) bool {
+	return index >= 0 && index < len(s.values)
+}
+
 func (s *SyncSlice[T]) Values() []T {
 	s.mx.Lock()
 	defer s.mx.Unlock()
@@ -94,3 +335,42 @@ func (s *SyncSlice[T]) Seq2() iter.Seq2[int, T] {
 		}
 	}
 }
+
+// MarshalJSON implements json.Marshaler, encoding values as JSON array
+func (s *SyncSlice[T]) MarshalJSON() ([]byte, error) {
+	values := s.Values()
+	if values == nil {
+		values = []T{}
+	}
+
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing values by the decoded JSON array.
+// In sorted-insert mode decoded values are sorted.
+func (s *SyncSlice[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if s.order != nil {
+		slices.SortStableFunc(values, s.order)
+	}
+	s.values = values
+
+	return nil
+}
+
+// String implements fmt.Stringer, formatting values like a plain slice
+func (s *SyncSlice[T]) String() string {
+	return fmt.Sprint(s.Values())
+}
+
+// Format implements fmt.Formatter, formatting values like a plain slice with the same verb and flags
+func (s *SyncSlice[T]) Format(f fmt.State, verb rune) {
+	_, _ = fmt.Fprintf(f, fmt.FormatString(f, verb), s.Values())
+}
@@ -1,10 +1,15 @@
 package dot
 
 import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
 	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSyncSlice_NewSyncSlice(t *testing.T) {
@@ -177,3 +182,243 @@ func TestSyncSlice_Seq2(t *testing.T) {
 		assert.Equal(t, src[k], v)
 	}
 }
+
+func TestSyncSlice_TryGet(t *testing.T) {
+	t.Parallel()
+
+	s := SyncSlice[int]{values: []int{1, 2, 3}}
+
+	tests := []struct {
+		name   string
+		index  int
+		expect int
+		ok     bool
+	}{
+		{"first", 0, 1, true},
+		{"last", 2, 3, true},
+		{"negative", -1, 0, false},
+		{"too big", 3, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			val, ok := s.TryGet(tt.index)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expect, val)
+		})
+	}
+}
+
+func TestSyncSlice_InsertAndRemove(t *testing.T) {
+	t.Parallel()
+
+	var s SyncSlice[int]
+	s.AppendAll(1, 4)
+	assert.True(t, s.Insert(1, 2, 3))
+	assert.True(t, s.Insert(4, 5))
+	assert.True(t, s.Insert(0, 0))
+	assert.False(t, s.Insert(7, 7))
+	assert.False(t, s.Insert(-1, 7))
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, s.Values())
+
+	val, ok := s.RemoveAt(2)
+	assert.True(t, ok)
+	assert.Equal(t, 2, val)
+	_, ok = s.RemoveAt(5)
+	assert.False(t, ok)
+
+	val, ok = s.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 5, val)
+	assert.Equal(t, []int{0, 1, 3, 4}, s.Values())
+
+	var empty SyncSlice[int]
+	_, ok = empty.Pop()
+	assert.False(t, ok)
+}
+
+func TestSyncSlice_UpdateAndSwap(t *testing.T) {
+	t.Parallel()
+
+	s := SyncSlice[int]{values: []int{1, 2, 3}}
+	double := func(v int) int { return v * 2 }
+
+	assert.True(t, s.Update(1, double))
+	assert.False(t, s.Update(3, double))
+	assert.True(t, s.Swap(0, 2))
+	assert.False(t, s.Swap(0, 3))
+	assert.Equal(t, []int{3, 4, 1}, s.Values())
+}
+
+func TestSyncSlice_TruncateClearDrain(t *testing.T) {
+	t.Parallel()
+
+	s := SyncSlice[int]{values: []int{1, 2, 3, 4}}
+	s.Truncate(5)
+	assert.Equal(t, 4, s.Len())
+	s.Truncate(2)
+	assert.Equal(t, []int{1, 2}, s.Values())
+
+	s.Clear()
+	assert.Equal(t, 0, s.Len())
+
+	s.AppendAll(5, 6)
+	assert.Equal(t, []int{5, 6}, s.DrainAll())
+	assert.Equal(t, 0, s.Len())
+	assert.Empty(t, s.DrainAll())
+}
+
+func TestSyncSlice_Filter(t *testing.T) {
+	t.Parallel()
+
+	s := SyncSlice[int]{values: []int{1, 2, 3, 4, 5}}
+	removed := s.Filter(func(v int) bool { return v%2 == 1 })
+	assert.Equal(t, 2, removed)
+	assert.Equal(t, []int{1, 3, 5}, s.Values())
+}
+
+func TestSyncSlice_ConcurrentPop(t *testing.T) {
+	t.Parallel()
+
+	const total = 1000
+
+	var s SyncSlice[int]
+	for i := range total {
+		s.Append(i)
+	}
+
+	var popped SyncSlice[int]
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				val, ok := s.Pop()
+				if !ok {
+					return
+				}
+				popped.Append(val)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, total, popped.Len())
+}
+
+func TestSyncSlice_SortAndSearch(t *testing.T) {
+	t.Parallel()
+
+	s := SyncSlice[int]{values: []int{5, 1, 4, 2, 3}}
+	s.SortFunc(cmp.Compare[int])
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, s.Values())
+
+	idx, found := s.BinarySearchFunc(4, cmp.Compare[int])
+	assert.True(t, found)
+	assert.Equal(t, 3, idx)
+	idx, found = s.BinarySearchFunc(10, cmp.Compare[int])
+	assert.False(t, found)
+	assert.Equal(t, 5, idx)
+
+	isEven := func(v int) bool { return v%2 == 0 }
+	assert.Equal(t, 1, s.IndexFunc(isEven))
+	assert.True(t, s.ContainsFunc(isEven))
+	assert.False(t, s.ContainsFunc(func(v int) bool { return v > 5 }))
+	assert.Equal(t, -1, s.IndexFunc(func(v int) bool { return v > 5 }))
+
+	s.Reverse()
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, s.Values())
+}
+
+func TestSyncSlice_SortStableFunc(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		key  int
+		name string
+	}
+	s := SyncSlice[item]{values: []item{{2, "a"}, {1, "b"}, {2, "c"}, {1, "d"}}}
+	s.SortStableFunc(func(a, b item) int { return cmp.Compare(a.key, b.key) })
+	assert.Equal(t, []item{{1, "b"}, {1, "d"}, {2, "a"}, {2, "c"}}, s.Values())
+}
+
+func TestCompactSyncSlice(t *testing.T) {
+	t.Parallel()
+
+	s := SyncSlice[int]{values: []int{1, 1, 2, 2, 2, 1, 3}}
+	CompactSyncSlice(&s)
+	assert.Equal(t, []int{1, 2, 1, 3}, s.Values())
+
+	s.CompactFunc(func(a, b int) bool { return a%2 == b%2 })
+	assert.Equal(t, []int{1, 2, 1}, s.Values())
+}
+
+func TestSyncSlice_KeepSorted(t *testing.T) {
+	t.Parallel()
+
+	s := SyncSlice[int]{values: []int{3, 1, 2}}
+	s.KeepSorted(cmp.Compare[int])
+	assert.Equal(t, []int{1, 2, 3}, s.Values())
+
+	s.Append(0)
+	s.AppendAll(5, 2, 4)
+	assert.Equal(t, []int{0, 1, 2, 2, 3, 4, 5}, s.Values())
+
+	sorted := NewSortedSyncSlice(0, func(a, b int) int { return cmp.Compare(b, a) })
+	var wg sync.WaitGroup
+	for g := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 10 {
+				sorted.Append(g*10 + i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	values := sorted.Values()
+	assert.Len(t, values, 100)
+	assert.True(t, slices.IsSortedFunc(values, func(a, b int) int { return cmp.Compare(b, a) }))
+}
+
+func TestSyncSlice_JSON(t *testing.T) {
+	t.Parallel()
+
+	type payload struct {
+		Items SyncSlice[int] `json:"items"`
+	}
+
+	var empty payload
+	data, err := json.Marshal(&empty)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"items":[]}`, string(data))
+
+	src := payload{Items: SyncSlice[int]{values: []int{3, 1, 2}}}
+	data, err = json.Marshal(&src)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"items":[3,1,2]}`, string(data))
+
+	var dst payload
+	require.NoError(t, json.Unmarshal(data, &dst))
+	assert.Equal(t, []int{3, 1, 2}, dst.Items.Values())
+
+	sorted := NewSortedSyncSlice(0, cmp.Compare[int])
+	require.NoError(t, json.Unmarshal([]byte(`[3,1,2]`), sorted))
+	assert.Equal(t, []int{1, 2, 3}, sorted.Values())
+
+	require.Error(t, json.Unmarshal([]byte(`{}`), sorted))
+}
+
+func TestSyncSlice_Format(t *testing.T) {
+	t.Parallel()
+
+	s := &SyncSlice[float64]{values: []float64{1.5, 2.25}}
+	assert.Equal(t, "[1.5 2.25]", s.String())
+	assert.Equal(t, "[1.5 2.25]", fmt.Sprint(s))
+	assert.Equal(t, "[1.50 2.25]", fmt.Sprintf("%.2f", s))
+	assert.Equal(t, "[]float64{1.5, 2.25}", fmt.Sprintf("%#v", s))
+}
@@ -1,12 +1,30 @@
 package dot
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"maps"
 	"sync"
 )
 
+// SyncStore - concurrency-safe map. It must not be copied after first use.
 type SyncStore[K comparable, V any] struct {
-	storage map[K]V
-	mx      sync.RWMutex
+	storage  map[K]V
+	calls    map[K]*storeCall[V] // constructions in progress, see GetOrPutErr
+	watchers map[*storeWatcher[K, V]]struct{}
+	journal  *StoreLog[K, V] // attached changes log, see OpenStoreLog
+	mx       sync.RWMutex
+}
+
+// storeCall - single value construction shared by concurrent callers of GetOrPutErr
+type storeCall[V any] struct {
+	done     chan struct{}
+	val      V
+	err      error
+	panicked bool
+	panicVal any
 }
 
 // Preallocate - init internal map with specified size
@@ -21,10 +39,7 @@ func (s *SyncStore[K, V]) Preallocate(mapSize int) {
 func (s *SyncStore[K, V]) Put(key K, val V) {
 	s.mx.Lock()
 	defer s.mx.Unlock()
-	if s.storage == nil {
-		s.storage = make(map[K]V)
-	}
-	s.storage[key] = val
+	s.set(key, val)
 }
 
 func (s *SyncStore[K, V]) GetCurrent(key K) (val V, founded bool) {
@@ -39,37 +54,249 @@ func (s *SyncStore[K, V]) GetCurrent(key K) (val V, founded bool) {
 	return val, founded
 }
 
+// GetOrPut - returns value of key, makes and stores it by maker if key is absent.
+// Concurrent calls for the same key make the value only once.
 func (s *SyncStore[K, V]) GetOrPut(key K, maker func() V) V {
+	return s.GetOrPutErr(key, func() (V, error) {
+		return maker(), nil
+	}).Val()
+}
+
+// GetOrPutErr - returns value of key, makes and stores it by maker if key is absent.
+// maker is called without holding the store lock, so other keys stay available during construction.
+// Concurrent calls for the same key wait for the single maker call and share its result.
+// Errors are not stored, so the next call makes a new attempt.
+// A panic in maker is re-raised in every waiting caller.
+func (s *SyncStore[K, V]) GetOrPutErr(key K, maker func() (V, error)) Result[V] {
 	val, founded := s.GetCurrent(key)
 	if founded {
-		return val
+		return MakeResult(val, nil)
 	}
 
 	s.mx.Lock()
-	defer s.mx.Unlock()
+	val, founded = s.storage[key]
+	if founded {
+		s.mx.Unlock()
+		return MakeResult(val, nil)
+	}
 
-	if s.storage == nil {
-		s.storage = make(map[K]V)
+	call, inProgress := s.calls[key]
+	if !inProgress {
+		call = &storeCall[V]{done: make(chan struct{})}
+		if s.calls == nil {
+			s.calls = make(map[K]*storeCall[V])
+		}
+		s.calls[key] = call
 	}
+	s.mx.Unlock()
 
-	val, founded = s.storage[key]
-	if founded {
-		return val
+	if inProgress {
+		<-call.done
+	} else {
+		s.makeCall(key, call, maker)
 	}
 
-	val = maker()
-	s.storage[key] = val
+	if call.panicked {
+		panic(call.panicVal)
+	}
 
-	return val
+	return MakeResult(call.val, call.err)
+}
+
+func (s *SyncStore[K, V]) makeCall(key K, call *storeCall[V], maker func() (V, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			call.panicked, call.panicVal = true, r
+		}
+
+		s.mx.Lock()
+		delete(s.calls, key)
+		if !call.panicked && call.err == nil {
+			if current, founded := s.storage[key]; founded {
+				// value was put while maker worked
+				call.val = current
+			} else {
+				s.set(key, call.val)
+			}
+		}
+		s.mx.Unlock()
+
+		close(call.done)
+	}()
+
+	call.val, call.err = maker()
 }
 
 func (s *SyncStore[K, V]) Del(key K) {
 	s.mx.Lock()
 	defer s.mx.Unlock()
-	delete(s.storage, key) // works with nil
+	s.remove(key)
+}
+
+// Update - atomically calls updater with current value of key and its presence flag.
+// The result of updater is stored if it returns true, otherwise the store stays unchanged.
+// Returns the actual value of key and presence flag.
+func (s *SyncStore[K, V]) Update(key K, updater func(old V, found bool) (V, bool)) (val V, found bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	val, found = s.storage[key]
+	newVal, ok := updater(val, found)
+	if !ok {
+		return val, found
+	}
+	s.set(key, newVal)
+
+	return newVal, true
+}
+
+// Swap - stores value and returns the previous one, if any
+func (s *SyncStore[K, V]) Swap(key K, val V) (previous V, loaded bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return s.set(key, val)
+}
+
+// LoadAndDelete - deletes key and returns its previous value, if any
+func (s *SyncStore[K, V]) LoadAndDelete(key K) (val V, loaded bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return s.remove(key)
+}
+
+// DeleteIf - deletes all pairs matched by predicate and returns count of deleted pairs.
+// predicate is called under the write lock and must not access the store.
+func (s *SyncStore[K, V]) DeleteIf(predicate func(key K, value V) bool) (deleted int) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	for k, v := range s.storage {
+		if predicate(k, v) {
+			s.remove(k)
+			deleted++
+		}
+	}
+
+	return deleted
 }
 
-// ForEach вызывает handler для каждой пары ключ-значение
+// PutAll - stores all pairs of m under a single lock
+func (s *SyncStore[K, V]) PutAll(m map[K]V) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	for k, v := range m {
+		s.set(k, v)
+	}
+}
+
+// PutSeq - stores all pairs of seq under a single lock.
+// seq is consumed before the lock is taken, so it may read the store.
+func (s *SyncStore[K, V]) PutSeq(seq iter.Seq2[K, V]) {
+	var records []storeRecord[K, V]
+	for k, v := range seq {
+		records = append(records, storeRecord[K, V]{Key: k, Value: v})
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	for _, rec := range records {
+		s.set(rec.Key, rec.Value)
+	}
+}
+
+// GetMany - returns found pairs of keys
+func (s *SyncStore[K, V]) GetMany(keys ...K) map[K]V {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	result := make(map[K]V, len(keys))
+	for _, k := range keys {
+		if v, ok := s.storage[k]; ok {
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+// DeleteMany - deletes keys under a single lock and returns count of deleted pairs
+func (s *SyncStore[K, V]) DeleteMany(keys ...K) (deleted int) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	for _, k := range keys {
+		if _, loaded := s.remove(k); loaded {
+			deleted++
+		}
+	}
+
+	return deleted
+}
+
+// Clear - deletes all pairs
+func (s *SyncStore[K, V]) Clear() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	for k := range s.storage {
+		s.remove(k)
+	}
+}
+
+// set stores value and notifies watchers, must be called under the write lock
+func (s *SyncStore[K, V]) set(key K, val V) (previous V, loaded bool) {
+	if s.storage == nil {
+		s.storage = make(map[K]V)
+	}
+	previous, loaded = s.storage[key]
+	s.storage[key] = val
+
+	if loaded {
+		s.notify(StoreEvent[K, V]{Kind: StoreEventUpdate, Key: key, Old: previous, New: val})
+	} else {
+		s.notify(StoreEvent[K, V]{Kind: StoreEventPut, Key: key, New: val})
+	}
+
+	return previous, loaded
+}
+
+// remove deletes key and notifies watchers, must be called under the write lock
+func (s *SyncStore[K, V]) remove(key K) (previous V, loaded bool) {
+	previous, loaded = s.storage[key]
+	if !loaded {
+		return previous, loaded
+	}
+	delete(s.storage, key)
+	s.notify(StoreEvent[K, V]{Kind: StoreEventDelete, Key: key, Old: previous})
+
+	return previous, loaded
+}
+
+// Len - returns count of stored pairs
+func (s *SyncStore[K, V]) Len() int {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	return len(s.storage)
+}
+
+// StoreUpdater - store with atomic read-modify-write operation
+type StoreUpdater[K comparable, V any] interface {
+	Update(key K, updater func(old V, found bool) (V, bool)) (V, bool)
+}
+
+// CompareAndSwap - atomically replaces value of key with newVal if current value equals to oldVal
+func CompareAndSwap[K, V comparable](store StoreUpdater[K, V], key K, oldVal, newVal V) (swapped bool) {
+	store.Update(key, func(current V, found bool) (V, bool) {
+		swapped = found && current == oldVal
+		return newVal, swapped
+	})
+
+	return swapped
+}
+
+// ForEach calls handler for every pair while holding the read lock.
+// handler must not modify the store, otherwise it deadlocks; use ForEachSnapshot for that.
 func (s *SyncStore[K, V]) ForEach(handler func(key K, value V)) {
 	s.mx.RLock()
 	defer s.mx.RUnlock()
@@ -78,29 +305,45 @@ func (s *SyncStore[K, V]) ForEach(handler func(key K, value V)) {
 	}
 }
 
+// ForEachSnapshot calls handler for every pair of the store copy made at the moment of call.
+// The lock is not held while handler works, so handler may modify the store.
+func (s *SyncStore[K, V]) ForEachSnapshot(handler func(key K, value V)) {
+	for k, v := range s.ToMap() {
+		handler(k, v)
+	}
+}
+
+// ToMap returns copy of stored pairs
+func (s *SyncStore[K, V]) ToMap() map[K]V {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	return maps.Clone(s.storage)
+}
+
+// Iterator returns channel of pairs of the store copy made at the moment of call.
+// The channel must be read to the end, otherwise the sending goroutine leaks.
+//
+// Deprecated: use IteratorCtx.
 func (s *SyncStore[K, V]) Iterator() <-chan struct {
 	Key   K
 	Value V
 } {
-	ch := make(chan struct {
-		Key   K
-		Value V
-	})
-	go func() {
-		s.mx.RLock()
-		defer s.mx.RUnlock()
-		for k, v := range s.storage {
-			ch <- struct {
-				Key   K
-				Value V
-			}{k, v}
-		}
-		close(ch)
-	}()
-	return ch
+	return s.IteratorCtx(context.Background())
 }
 
-// Seq реализует iter.Seq (итерация по значениям)
+// IteratorCtx returns channel of pairs of the store copy made at the moment of call.
+// The lock is released before the first pair is sent. The channel is closed after the last pair
+// or on context cancellation, so the consumer may stop reading after canceling ctx.
+func (s *SyncStore[K, V]) IteratorCtx(ctx context.Context) <-chan struct {
+	Key   K
+	Value V
+} {
+	return pairsChan(ctx, maps.All(s.ToMap()))
+}
+
+// Seq implements iter.Seq over values while holding the read lock during the whole iteration.
+// Loop body must not modify the store, otherwise it deadlocks; use SnapshotSeq for that.
 func (s *SyncStore[K, V]) Seq() func(yield func(V) bool) {
 	return func(yield func(V) bool) {
 		s.mx.RLock()
@@ -113,7 +356,8 @@ func (s *SyncStore[K, V]) Seq() func(yield func(V) bool) {
 	}
 }
 
-// Seq2 реализует iter.Seq2 (итерация по ключу и значению)
+// Seq2 implements iter.Seq2 over keys and values while holding the read lock during the whole iteration.
+// Loop body must not modify the store, otherwise it deadlocks; use SnapshotSeq2 for that.
 func (s *SyncStore[K, V]) Seq2() func(yield func(K, V) bool) {
 	return func(yield func(K, V) bool) {
 		s.mx.RLock()
@@ -125,3 +369,85 @@ func (s *SyncStore[K, V]) Seq2() func(yield func(K, V) bool) {
 		}
 	}
 }
+
+// SnapshotSeq implements iter.Seq over values of the store copy made at the start of iteration.
+// The lock is not held during iteration, so loop body may modify the store.
+func (s *SyncStore[K, V]) SnapshotSeq() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range s.ToMap() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SnapshotSeq2 implements iter.Seq2 over pairs of the store copy made at the start of iteration.
+// The lock is not held during iteration, so loop body may modify the store.
+func (s *SyncStore[K, V]) SnapshotSeq2() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range s.ToMap() {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// pairsChan sends pairs of snapshot to the returned channel until the end or ctx cancellation
+func pairsChan[K comparable, V any](ctx context.Context, snapshot iter.Seq2[K, V]) <-chan struct {
+	Key   K
+	Value V
+} {
+	ch := make(chan struct {
+		Key   K
+		Value V
+	})
+	go func() {
+		defer close(ch)
+		for k, v := range snapshot {
+			select {
+			case ch <- struct {
+				Key   K
+				Value V
+			}{k, v}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// MarshalJSON implements json.Marshaler, encoding pairs as JSON object.
+// Keys must be strings, integers or implement encoding.TextMarshaler.
+func (s *SyncStore[K, V]) MarshalJSON() ([]byte, error) {
+	m := s.ToMap()
+	if m == nil {
+		m = map[K]V{}
+	}
+
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing pairs by the decoded JSON object.
+// Watchers get events for every change.
+func (s *SyncStore[K, V]) UnmarshalJSON(data []byte) error {
+	var m map[K]V
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	s.replaceAll(m)
+
+	return nil
+}
+
+// String implements fmt.Stringer, formatting pairs like a plain map
+func (s *SyncStore[K, V]) String() string {
+	return fmt.Sprint(s.ToMap())
+}
+
+// Format implements fmt.Formatter, formatting pairs like a plain map with the same verb and flags
+func (s *SyncStore[K, V]) Format(f fmt.State, verb rune) {
+	_, _ = fmt.Fprintf(f, fmt.FormatString(f, verb), s.ToMap())
+}
@@ -0,0 +1,326 @@
+package dot
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Codec - serialization format of SyncStore snapshots
+type Codec interface {
+	Encode(w io.Writer, v any) error
+	Decode(r io.Reader, v any) error
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Encode(w io.Writer, v any) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+func (jsonCodec) Decode(r io.Reader, v any) error {
+	return json.NewDecoder(r).Decode(v)
+}
+
+type gobCodec struct{}
+
+func (gobCodec) Encode(w io.Writer, v any) error {
+	return gob.NewEncoder(w).Encode(v)
+}
+
+func (gobCodec) Decode(r io.Reader, v any) error {
+	return gob.NewDecoder(r).Decode(v)
+}
+
+var (
+	JSONCodec Codec = jsonCodec{} // encoding/json based codec
+	GobCodec  Codec = gobCodec{}  // encoding/gob based codec
+)
+
+var errStoreLogAttached = errors.New("store already has attached log")
+
+// storeRecord - serialized key-value pair, lets any comparable keys be encoded by any codec
+type storeRecord[K comparable, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// Snapshot - writes copy of stored pairs by codec. Nil codec means JSONCodec.
+// The lock is held only while the copy is made.
+func (s *SyncStore[K, V]) Snapshot(w io.Writer, codec Codec) error {
+	snapshot := s.ToMap()
+	records := make([]storeRecord[K, V], 0, len(snapshot))
+	for k, v := range snapshot {
+		records = append(records, storeRecord[K, V]{Key: k, Value: v})
+	}
+
+	return Iif(codec == nil, JSONCodec, codec).Encode(w, records)
+}
+
+// Restore - replaces store content by the snapshot read by codec. Nil codec means JSONCodec.
+// Watchers get events for every change.
+func (s *SyncStore[K, V]) Restore(r io.Reader, codec Codec) error {
+	var records []storeRecord[K, V]
+	if err := Iif(codec == nil, JSONCodec, codec).Decode(r, &records); err != nil {
+		return fmt.Errorf("decode snapshot: %w", err)
+	}
+
+	restored := make(map[K]V, len(records))
+	for _, rec := range records {
+		restored[rec.Key] = rec.Value
+	}
+	s.replaceAll(restored)
+
+	return nil
+}
+
+// replaceAll replaces store content by pairs of m, notifying watchers about every change
+func (s *SyncStore[K, V]) replaceAll(m map[K]V) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	for k := range s.storage {
+		if _, ok := m[k]; !ok {
+			s.remove(k)
+		}
+	}
+	for k, v := range m {
+		s.set(k, v)
+	}
+}
+
+// SnapshotFile - atomically writes snapshot to file, see Snapshot and WriteFileAtomic
+func (s *SyncStore[K, V]) SnapshotFile(path string, codec Codec) error {
+	return WriteFileAtomic(path, func(w io.Writer) error {
+		return s.Snapshot(w, codec)
+	})
+}
+
+// RestoreFile - restores store from snapshot file, see Restore
+func (s *SyncStore[K, V]) RestoreFile(path string, codec Codec) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return s.Restore(file, codec)
+}
+
+// WriteFileAtomic - writes file content by writer into temporary file in the same directory,
+// then renames it to path. Readers see either the old or the new file content, never a partial one.
+// Permissions of existing file are kept, new file is created with mode 0600.
+func WriteFileAtomic(path string, writer func(w io.Writer) error) (err error) {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if info, statErr := os.Stat(path); statErr == nil {
+		if err = tmp.Chmod(info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	if err = writer(tmp); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	return syncDir(dir)
+}
+
+// syncDir flushes directory entries, so the rename survives a crash
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(d.Sync(), d.Close())
+}
+
+const (
+	storeLogOpPut = "put"
+	storeLogOpDel = "del"
+)
+
+// storeLogRecord - single change in the StoreLog file
+type storeLogRecord[K comparable, V any] struct {
+	Op    string `json:"op"`
+	Key   K      `json:"key"`
+	Value V      `json:"value"`
+}
+
+// StoreLog - append-only JSON lines log of SyncStore changes
+type StoreLog[K comparable, V any] struct {
+	mx     sync.Mutex
+	store  *SyncStore[K, V]
+	path   string
+	file   *os.File
+	enc    *json.Encoder
+	err    error
+	closed bool
+}
+
+// OpenStoreLog - replays puts and deletes from the log file into store,
+// then appends every store change to the file until Close.
+// The store is write-locked during replay, so no change is made between replay and attaching the log.
+// A partially written last record, left by a crash, is discarded.
+func OpenStoreLog[K comparable, V any](path string, store *SyncStore[K, V]) (*StoreLog[K, V], error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0o600) //nolint:mnd
+	if err != nil {
+		return nil, err
+	}
+
+	store.mx.Lock()
+	defer store.mx.Unlock()
+
+	if store.journal != nil {
+		_ = file.Close()
+		return nil, errStoreLogAttached
+	}
+
+	if err = replayStoreLog(file, store); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("replay store log %s: %w", path, err)
+	}
+
+	l := &StoreLog[K, V]{store: store, path: path}
+	l.setFile(file)
+	store.journal = l
+
+	return l, nil
+}
+
+// replayStoreLog applies log records to store, must be called under the store write lock
+func replayStoreLog[K comparable, V any](file *os.File, store *SyncStore[K, V]) error {
+	dec := json.NewDecoder(file)
+	for {
+		offset := dec.InputOffset()
+		var rec storeLogRecord[K, V]
+		err := dec.Decode(&rec)
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case errors.Is(err, io.ErrUnexpectedEOF):
+			return file.Truncate(offset)
+		case err != nil:
+			return err
+		}
+
+		switch rec.Op {
+		case storeLogOpPut:
+			store.set(rec.Key, rec.Value)
+		case storeLogOpDel:
+			store.remove(rec.Key)
+		default:
+			return fmt.Errorf("unknown operation %q at offset %d", rec.Op, offset)
+		}
+	}
+}
+
+func (l *StoreLog[K, V]) setFile(file *os.File) {
+	l.file = file
+	l.enc = json.NewEncoder(file)
+}
+
+// write appends store change to the log, called under the store write lock
+func (l *StoreLog[K, V]) write(event StoreEvent[K, V]) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	if l.err != nil {
+		return
+	}
+
+	rec := storeLogRecord[K, V]{Op: storeLogOpPut, Key: event.Key, Value: event.New}
+	if event.Kind == StoreEventDelete {
+		rec.Op = storeLogOpDel
+	}
+	l.err = l.enc.Encode(rec)
+}
+
+// Err - returns the first write error. The log stops writing after an error.
+func (l *StoreLog[K, V]) Err() error {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	return l.err
+}
+
+// Compact - atomically rewrites the log with the current store content as puts only.
+// Returns os.ErrClosed after Close.
+func (l *StoreLog[K, V]) Compact() error {
+	l.store.mx.Lock()
+	defer l.store.mx.Unlock()
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	if l.closed {
+		return os.ErrClosed
+	}
+	if l.err != nil {
+		return l.err
+	}
+
+	err := WriteFileAtomic(l.path, func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		for k, v := range l.store.storage {
+			if err := enc.Encode(storeLogRecord[K, V]{Op: storeLogOpPut, Key: k, Value: v}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND, 0o600) //nolint:mnd
+	if err != nil {
+		l.err = err
+		return err
+	}
+	_ = l.file.Close()
+	l.setFile(file)
+
+	return nil
+}
+
+// Close - detaches the log from the store and closes the file. Repeated calls do nothing.
+func (l *StoreLog[K, V]) Close() error {
+	l.store.mx.Lock()
+	if l.store.journal == l {
+		l.store.journal = nil
+	}
+	l.store.mx.Unlock()
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+
+	return errors.Join(l.file.Sync(), l.file.Close())
+}
@@ -0,0 +1,230 @@
+package dot_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirrorru/dot"
+)
+
+type persistKey struct {
+	Group string
+	ID    int
+}
+
+func TestSyncStore_SnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		codec dot.Codec
+	}{
+		{name: "default", codec: nil},
+		{name: "json", codec: dot.JSONCodec},
+		{name: "gob", codec: dot.GobCodec},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var src dot.SyncStore[persistKey, string]
+			src.Put(persistKey{Group: "a", ID: 1}, "one")
+			src.Put(persistKey{Group: "b", ID: 2}, "two")
+
+			buf := bytes.Buffer{}
+			require.NoError(t, src.Snapshot(&buf, tt.codec))
+
+			var dst dot.SyncStore[persistKey, string]
+			dst.Put(persistKey{Group: "c", ID: 3}, "stale")
+			require.NoError(t, dst.Restore(&buf, tt.codec))
+			assert.Equal(t, src.ToMap(), dst.ToMap())
+		})
+	}
+}
+
+func TestSyncStore_RestoreError(t *testing.T) {
+	t.Parallel()
+
+	var s dot.SyncStore[string, int]
+	s.Put("a", 1)
+	require.Error(t, s.Restore(bytes.NewBufferString("not a json"), nil))
+	assert.Equal(t, map[string]int{"a": 1}, s.ToMap())
+}
+
+func TestSyncStore_SnapshotFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "store.json")
+
+	var src dot.SyncStore[string, int]
+	src.Put("a", 1)
+	require.NoError(t, src.SnapshotFile(path, nil))
+	src.Put("b", 2)
+	require.NoError(t, src.SnapshotFile(path, nil))
+
+	var dst dot.SyncStore[string, int]
+	require.NoError(t, dst.RestoreFile(path, nil))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, dst.ToMap())
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files must not be left")
+}
+
+func TestWriteFileAtomic_Error(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0o600))
+
+	err := dot.WriteFileAtomic(path, func(w io.Writer) error {
+		_, _ = w.Write([]byte("partial"))
+		return assert.AnError
+	})
+	require.ErrorIs(t, err, assert.AnError)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(content))
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestWriteFileAtomic_KeepsMode(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0o644))
+	require.NoError(t, os.Chmod(path, 0o644))
+
+	err := dot.WriteFileAtomic(path, func(w io.Writer) error {
+		_, err := w.Write([]byte("new"))
+		return err
+	})
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(content))
+}
+
+func TestStoreLog(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "store.log")
+
+	var s1 dot.SyncStore[string, int]
+	log1, err := dot.OpenStoreLog(path, &s1)
+	require.NoError(t, err)
+	_, err = dot.OpenStoreLog(path, &s1)
+	require.Error(t, err)
+
+	s1.Put("a", 1)
+	s1.Put("b", 2)
+	s1.Put("a", 3)
+	s1.Del("b")
+	s1.Put("c", 4)
+	require.NoError(t, log1.Err())
+	require.NoError(t, log1.Close())
+	s1.Put("d", 5) // not logged after Close
+
+	// emulate crash during write of the last record
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"op":"put","key":"e","val`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	var s2 dot.SyncStore[string, int]
+	log2, err := dot.OpenStoreLog(path, &s2)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"a": 3, "c": 4}, s2.ToMap())
+
+	s2.Put("f", 6)
+	require.NoError(t, log2.Compact())
+	s2.Del("a")
+	require.NoError(t, log2.Close())
+
+	var s3 dot.SyncStore[string, int]
+	log3, err := dot.OpenStoreLog(path, &s3)
+	require.NoError(t, err)
+	require.NoError(t, log3.Close())
+	assert.Equal(t, map[string]int{"c": 4, "f": 6}, s3.ToMap())
+}
+
+func TestStoreLog_ConcurrentWritesDuringOpen(t *testing.T) {
+	t.Parallel()
+
+	const keys = 2000
+	path := filepath.Join(t.TempDir(), "store.log")
+
+	var prepared dot.SyncStore[int, int]
+	prepareLog, err := dot.OpenStoreLog(path, &prepared)
+	require.NoError(t, err)
+	for k := range keys {
+		prepared.Put(k, -1)
+	}
+	require.NoError(t, prepareLog.Close())
+
+	// writers race with replay, their changes must be neither overwritten nor lost for the log
+	var s dot.SyncStore[int, int]
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := w; k < keys; k += 4 {
+				s.Put(k, k)
+			}
+		}()
+	}
+	storeLog, err := dot.OpenStoreLog(path, &s)
+	require.NoError(t, err)
+	wg.Wait()
+	require.NoError(t, storeLog.Close())
+
+	var reopened dot.SyncStore[int, int]
+	reopenedLog, err := dot.OpenStoreLog(path, &reopened)
+	require.NoError(t, err)
+	require.NoError(t, reopenedLog.Close())
+	assert.Equal(t, s.ToMap(), reopened.ToMap())
+}
+
+func TestStoreLog_CloseTwiceAndReopen(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "store.log")
+	var s dot.SyncStore[string, int]
+
+	logA, err := dot.OpenStoreLog(path, &s)
+	require.NoError(t, err)
+	s.Put("a", 1)
+	require.NoError(t, logA.Close())
+	require.NoError(t, logA.Close())
+	require.ErrorIs(t, logA.Compact(), os.ErrClosed)
+
+	logB, err := dot.OpenStoreLog(path, &s)
+	require.NoError(t, err)
+	require.NoError(t, logA.Close(), "stale Close must not detach another log")
+	s.Put("x", 1)
+	require.NoError(t, logB.Close())
+
+	var replayed dot.SyncStore[string, int]
+	replayLog, err := dot.OpenStoreLog(path, &replayed)
+	require.NoError(t, err)
+	require.NoError(t, replayLog.Close())
+	assert.Equal(t, map[string]int{"a": 1, "x": 1}, replayed.ToMap())
+}
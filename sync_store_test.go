@@ -1,11 +1,17 @@
 package dot_test
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/mirrorru/dot"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSyncStore_Basic(t *testing.T) {
@@ -137,3 +143,332 @@ func TestSyncStore_Concurrent(t *testing.T) {
 	})
 	assert.Equal(t, 100, count)
 }
+
+func TestSyncStore_Update(t *testing.T) {
+	t.Parallel()
+
+	var s dot.SyncStore[string, int]
+	increment := func(old int, _ bool) (int, bool) {
+		return old + 1, true
+	}
+
+	v, ok := s.Update("a", increment)
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	v, ok = s.Update("a", increment)
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+
+	v, ok = s.Update("a", func(old int, found bool) (int, bool) {
+		assert.True(t, found)
+		assert.Equal(t, 2, old)
+		return 100, false
+	})
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+
+	v, ok = s.Update("b", func(int, bool) (int, bool) {
+		return 0, false
+	})
+	assert.False(t, ok)
+	assert.Equal(t, 0, v)
+	assert.Equal(t, 1, s.Len())
+}
+
+func TestSyncStore_ConcurrentUpdate(t *testing.T) {
+	t.Parallel()
+
+	const goroutines = 50
+
+	var s dot.SyncStore[string, int]
+	wg := sync.WaitGroup{}
+	wg.Add(goroutines)
+	for range goroutines {
+		go func() {
+			defer wg.Done()
+			s.Update("counter", func(old int, _ bool) (int, bool) {
+				return old + 1, true
+			})
+		}()
+	}
+	wg.Wait()
+
+	v, _ := s.GetCurrent("counter")
+	assert.Equal(t, goroutines, v)
+}
+
+func TestSyncStore_CompareAndSwap(t *testing.T) {
+	t.Parallel()
+
+	var s dot.SyncStore[string, int]
+	assert.False(t, dot.CompareAndSwap(&s, "a", 0, 1))
+	_, ok := s.GetCurrent("a")
+	assert.False(t, ok)
+
+	s.Put("a", 1)
+	assert.False(t, dot.CompareAndSwap(&s, "a", 2, 3))
+	assert.True(t, dot.CompareAndSwap(&s, "a", 1, 3))
+	v, _ := s.GetCurrent("a")
+	assert.Equal(t, 3, v)
+}
+
+func TestSyncStore_SwapAndLoadAndDelete(t *testing.T) {
+	t.Parallel()
+
+	var s dot.SyncStore[string, int]
+	prev, loaded := s.Swap("a", 1)
+	assert.False(t, loaded)
+	assert.Equal(t, 0, prev)
+
+	prev, loaded = s.Swap("a", 2)
+	assert.True(t, loaded)
+	assert.Equal(t, 1, prev)
+
+	v, loaded := s.LoadAndDelete("a")
+	assert.True(t, loaded)
+	assert.Equal(t, 2, v)
+
+	_, loaded = s.LoadAndDelete("a")
+	assert.False(t, loaded)
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestSyncStore_DeleteIf(t *testing.T) {
+	t.Parallel()
+
+	var s dot.SyncStore[int, int]
+	assert.Equal(t, 0, s.DeleteIf(func(int, int) bool { return true }))
+
+	for i := range 10 {
+		s.Put(i, i*i)
+	}
+	deleted := s.DeleteIf(func(k, _ int) bool {
+		return k%2 == 0
+	})
+	assert.Equal(t, 5, deleted)
+	assert.Equal(t, 5, s.Len())
+	s.ForEach(func(k, _ int) {
+		assert.Equal(t, 1, k%2)
+	})
+}
+
+func TestSyncStore_GetOrPutErr(t *testing.T) {
+	t.Parallel()
+
+	var s dot.SyncStore[string, int]
+
+	res := s.GetOrPutErr("a", func() (int, error) {
+		return 0, assert.AnError
+	})
+	require.ErrorIs(t, res.Err(), assert.AnError)
+	_, ok := s.GetCurrent("a")
+	assert.False(t, ok, "errors must not be stored")
+
+	res = s.GetOrPutErr("a", func() (int, error) {
+		return 1, nil
+	})
+	require.NoError(t, res.Err())
+	assert.Equal(t, 1, res.Val())
+
+	res = s.GetOrPutErr("a", func() (int, error) {
+		return 2, nil
+	})
+	require.NoError(t, res.Err())
+	assert.Equal(t, 1, res.Val())
+}
+
+func TestSyncStore_GetOrPutErr_Dedup(t *testing.T) {
+	t.Parallel()
+
+	const goroutines = 20
+
+	var s dot.SyncStore[string, int]
+	var calls atomic.Int32
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	wg := sync.WaitGroup{}
+	results := make([]dot.Result[int], goroutines)
+	wg.Add(goroutines)
+	for i := range goroutines {
+		go func() {
+			defer wg.Done()
+			results[i] = s.GetOrPutErr("slow", func() (int, error) {
+				if calls.Add(1) == 1 {
+					close(started)
+				}
+				<-release
+				return 42, nil
+			})
+		}()
+	}
+
+	<-started
+	// other keys are not blocked by slow construction
+	assert.Equal(t, 1, s.GetOrPut("fast", func() int { return 1 }))
+	s.Put("other", 2)
+
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for _, res := range results {
+		require.NoError(t, res.Err())
+		assert.Equal(t, 42, res.Val())
+	}
+}
+
+func TestSyncStore_GetOrPutErr_Panic(t *testing.T) {
+	t.Parallel()
+
+	const goroutines = 5
+
+	var s dot.SyncStore[string, int]
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var startOnce sync.Once
+	var waiting sync.WaitGroup
+
+	panics := make(chan any, goroutines)
+	wg := sync.WaitGroup{}
+	wg.Add(goroutines)
+	waiting.Add(goroutines - 1)
+	for i := range goroutines {
+		go func() {
+			defer wg.Done()
+			defer func() {
+				panics <- recover()
+			}()
+			if i > 0 {
+				<-started
+				waiting.Done()
+			}
+			s.GetOrPutErr("a", func() (int, error) {
+				startOnce.Do(func() { close(started) })
+				<-release
+				panic("boom")
+			})
+		}()
+	}
+
+	waiting.Wait()
+	time.Sleep(10 * time.Millisecond) // let waiters reach the in-progress call
+	close(release)
+	wg.Wait()
+	close(panics)
+
+	for p := range panics {
+		assert.Equal(t, "boom", p)
+	}
+	_, ok := s.GetCurrent("a")
+	assert.False(t, ok)
+}
+
+func TestSyncStore_SnapshotIteration(t *testing.T) {
+	t.Parallel()
+
+	var s dot.SyncStore[int, int]
+	for i := range 10 {
+		s.Put(i, i)
+	}
+
+	// modifications inside loops must not deadlock and must not affect running iteration
+	count := 0
+	for k, v := range s.SnapshotSeq2() {
+		s.Put(k+100, v+100)
+		count++
+	}
+	assert.Equal(t, 10, count)
+	assert.Equal(t, 20, s.Len())
+
+	count = 0
+	for v := range s.SnapshotSeq() {
+		s.Del(v)
+		count++
+	}
+	assert.Equal(t, 20, count)
+	assert.Equal(t, 0, s.Len())
+
+	s.Put(1, 1)
+	s.ForEachSnapshot(func(k, v int) {
+		s.Put(k+1, v+1)
+	})
+	assert.Equal(t, map[int]int{1: 1, 2: 2}, s.ToMap())
+}
+
+func TestSyncStore_IteratorCtx(t *testing.T) {
+	t.Parallel()
+
+	var s dot.SyncStore[int, int]
+	for i := range 10 {
+		s.Put(i, i)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	ch := s.IteratorCtx(ctx)
+	<-ch
+	s.Put(100, 100) // lock is not held by iterator
+	cancel()
+
+	for range ch { //nolint:revive
+		// drain until channel is closed
+	}
+	assert.Equal(t, 11, s.Len())
+}
+
+func TestSyncStore_Bulk(t *testing.T) {
+	t.Parallel()
+
+	var s dot.SyncStore[int, string]
+	s.PutAll(map[int]string{1: "one", 2: "two"})
+	s.PutSeq(func(yield func(int, string) bool) {
+		for k, v := range map[int]string{3: "three", 4: "four"} {
+			if !yield(k, v) {
+				return
+			}
+		}
+	})
+	assert.Equal(t, 4, s.Len())
+
+	assert.Equal(t, map[int]string{1: "one", 4: "four"}, s.GetMany(1, 4, 5))
+	assert.Equal(t, 2, s.DeleteMany(1, 2, 5))
+	assert.Equal(t, map[int]string{3: "three", 4: "four"}, s.ToMap())
+
+	s.Clear()
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestSyncStore_JSON(t *testing.T) {
+	t.Parallel()
+
+	var empty dot.SyncStore[string, int]
+	data, err := json.Marshal(&empty)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(data))
+
+	var src dot.SyncStore[string, int]
+	src.PutAll(map[string]int{"a": 1, "b": 2})
+	data, err = json.Marshal(&src)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a":1,"b":2}`, string(data))
+
+	var dst dot.SyncStore[string, int]
+	dst.Put("c", 3)
+	events := dst.WatchAll(t.Context())
+	require.NoError(t, json.Unmarshal(data, &dst))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, dst.ToMap())
+	assert.Len(t, drainEvents(events), 3)
+
+	require.Error(t, json.Unmarshal([]byte(`[]`), &dst))
+}
+
+func TestSyncStore_Format(t *testing.T) {
+	t.Parallel()
+
+	var s dot.SyncStore[string, int]
+	s.PutAll(map[string]int{"b": 2, "a": 1})
+	assert.Equal(t, "map[a:1 b:2]", s.String())
+	assert.Equal(t, "map[a:1 b:2]", fmt.Sprint(&s))
+	assert.Equal(t, `map[string]int{"a":1, "b":2}`, fmt.Sprintf("%#v", &s))
+}
@@ -0,0 +1,57 @@
+package dot
+
+// StoreTx - SyncStore transaction, see SyncStore.Transaction.
+// Writes are buffered and become visible to other store users only after commit.
+type StoreTx[K comparable, V any] struct {
+	store   *SyncStore[K, V]
+	changes map[K]Option[V] // empty Option means deleted key
+	order   []K             // changed keys in order of the first change
+}
+
+// Transaction - calls fn with transaction holding the store write lock.
+// Changes made through tx are applied atomically if fn returns nil and discarded if fn returns error or panics.
+// fn must not call the store methods directly and tx must not be used after fn returns.
+func (s *SyncStore[K, V]) Transaction(fn func(tx *StoreTx[K, V]) error) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	tx := &StoreTx[K, V]{store: s, changes: make(map[K]Option[V])}
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	for _, k := range tx.order {
+		if change := tx.changes[k]; change.Ok {
+			s.set(k, change.Val)
+		} else {
+			s.remove(k)
+		}
+	}
+
+	return nil
+}
+
+// Get - returns value of key, taking into account changes made by the transaction
+func (tx *StoreTx[K, V]) Get(key K) (val V, founded bool) {
+	if change, changed := tx.changes[key]; changed {
+		return change.Val, change.Ok
+	}
+	val, founded = tx.store.storage[key]
+
+	return val, founded
+}
+
+func (tx *StoreTx[K, V]) Put(key K, val V) {
+	tx.change(key, ToOption(val))
+}
+
+func (tx *StoreTx[K, V]) Del(key K) {
+	tx.change(key, Option[V]{})
+}
+
+func (tx *StoreTx[K, V]) change(key K, change Option[V]) {
+	if _, changed := tx.changes[key]; !changed {
+		tx.order = append(tx.order, key)
+	}
+	tx.changes[key] = change
+}
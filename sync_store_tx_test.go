@@ -0,0 +1,75 @@
+package dot_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirrorru/dot"
+)
+
+func TestSyncStore_Transaction(t *testing.T) {
+	t.Parallel()
+
+	var s dot.SyncStore[string, int]
+	s.PutAll(map[string]int{"alice": 100, "bob": 50})
+	events := s.WatchAll(t.Context())
+
+	transfer := func(from, to string, amount int) error {
+		return s.Transaction(func(tx *dot.StoreTx[string, int]) error {
+			fromBalance, _ := tx.Get(from)
+			toBalance, _ := tx.Get(to)
+			tx.Put(from, fromBalance-amount)
+			tx.Put(to, toBalance+amount)
+			if balance, _ := tx.Get(from); balance < 0 {
+				return errors.New("insufficient funds")
+			}
+			return nil
+		})
+	}
+
+	require.NoError(t, transfer("alice", "bob", 30))
+	assert.Equal(t, map[string]int{"alice": 70, "bob": 80}, s.ToMap())
+	assert.Len(t, drainEvents(events), 2)
+
+	require.Error(t, transfer("bob", "carol", 100))
+	assert.Equal(t, map[string]int{"alice": 70, "bob": 80}, s.ToMap())
+	assert.Empty(t, drainEvents(events), "rolled back changes must not be visible")
+}
+
+func TestSyncStore_TransactionDelete(t *testing.T) {
+	t.Parallel()
+
+	var s dot.SyncStore[string, int]
+	s.Put("a", 1)
+
+	err := s.Transaction(func(tx *dot.StoreTx[string, int]) error {
+		tx.Del("a")
+		_, ok := tx.Get("a")
+		assert.False(t, ok)
+		tx.Put("b", 2)
+		tx.Del("b")
+		tx.Put("c", 3)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"c": 3}, s.ToMap())
+}
+
+func TestSyncStore_TransactionPanic(t *testing.T) {
+	t.Parallel()
+
+	var s dot.SyncStore[string, int]
+	s.Put("a", 1)
+
+	assert.Panics(t, func() {
+		_ = s.Transaction(func(tx *dot.StoreTx[string, int]) error {
+			tx.Put("a", 2)
+			panic("boom")
+		})
+	})
+	v, _ := s.GetCurrent("a")
+	assert.Equal(t, 1, v)
+}
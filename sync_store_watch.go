@@ -0,0 +1,132 @@
+package dot
+
+import (
+	"context"
+)
+
+// StoreEventKind - kind of SyncStore change
+type StoreEventKind int
+
+const (
+	StoreEventPut    StoreEventKind = iota + 1 // new key stored
+	StoreEventUpdate                           // value of existing key replaced
+	StoreEventDelete                           // key deleted
+)
+
+// StoreEvent - SyncStore change notification.
+// Old is empty for StoreEventPut, New is empty for StoreEventDelete.
+type StoreEvent[K comparable, V any] struct {
+	Kind StoreEventKind
+	Key  K
+	Old  V
+	New  V
+}
+
+// DropPolicy - rule of choosing event to drop when watcher channel is full
+type DropPolicy int
+
+const (
+	DropNewest DropPolicy = iota // drops the event being delivered
+	DropOldest                   // drops the oldest event in the channel to make room for the new one
+)
+
+const defaultWatchBuffer = 64
+
+// WatchOption - watcher configuration option for Watch and WatchAll
+type WatchOption func(cfg *watchConfig)
+
+type watchConfig struct {
+	buffer int
+	policy DropPolicy
+}
+
+// WithWatchBuffer - sets capacity of watcher channel, 64 by default, at least 1
+func WithWatchBuffer(size int) WatchOption {
+	return func(cfg *watchConfig) {
+		cfg.buffer = max(size, 1)
+	}
+}
+
+// WithWatchDropPolicy - sets policy applied when watcher channel is full, DropNewest by default
+func WithWatchDropPolicy(policy DropPolicy) WatchOption {
+	return func(cfg *watchConfig) {
+		cfg.policy = policy
+	}
+}
+
+type storeWatcher[K comparable, V any] struct {
+	ch     chan StoreEvent[K, V]
+	key    K
+	all    bool
+	policy DropPolicy
+}
+
+// Watch - subscribes to changes of key.
+// Events are delivered without blocking the store: when the channel is full, events are dropped by policy.
+// The channel is closed after ctx cancellation.
+func (s *SyncStore[K, V]) Watch(ctx context.Context, key K, opts ...WatchOption) <-chan StoreEvent[K, V] {
+	return s.watch(ctx, &storeWatcher[K, V]{key: key}, opts)
+}
+
+// WatchAll - subscribes to changes of all keys, see Watch
+func (s *SyncStore[K, V]) WatchAll(ctx context.Context, opts ...WatchOption) <-chan StoreEvent[K, V] {
+	return s.watch(ctx, &storeWatcher[K, V]{all: true}, opts)
+}
+
+func (s *SyncStore[K, V]) watch(ctx context.Context, w *storeWatcher[K, V], opts []WatchOption) <-chan StoreEvent[K, V] {
+	cfg := watchConfig{buffer: defaultWatchBuffer, policy: DropNewest}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	w.ch = make(chan StoreEvent[K, V], cfg.buffer)
+	w.policy = cfg.policy
+
+	s.mx.Lock()
+	if s.watchers == nil {
+		s.watchers = make(map[*storeWatcher[K, V]]struct{})
+	}
+	s.watchers[w] = struct{}{}
+	s.mx.Unlock()
+
+	context.AfterFunc(ctx, func() {
+		s.mx.Lock()
+		defer s.mx.Unlock()
+		delete(s.watchers, w)
+		close(w.ch)
+	})
+
+	return w.ch
+}
+
+// notify delivers event to journal and watchers, must be called under the write lock
+func (s *SyncStore[K, V]) notify(event StoreEvent[K, V]) {
+	if s.journal != nil {
+		s.journal.write(event)
+	}
+	for w := range s.watchers {
+		if w.all || w.key == event.Key {
+			w.deliver(event)
+		}
+	}
+}
+
+func (w *storeWatcher[K, V]) deliver(event StoreEvent[K, V]) {
+	select {
+	case w.ch <- event:
+		return
+	default:
+	}
+
+	if w.policy != DropOldest {
+		return
+	}
+
+	select {
+	case <-w.ch:
+	default:
+	}
+	select {
+	case w.ch <- event:
+	default:
+	}
+}
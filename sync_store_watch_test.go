@@ -0,0 +1,114 @@
+package dot_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mirrorru/dot"
+)
+
+func drainEvents[K comparable, V any](ch <-chan dot.StoreEvent[K, V]) []dot.StoreEvent[K, V] {
+	var events []dot.StoreEvent[K, V]
+	for {
+		select {
+		case ev := <-ch:
+			events = append(events, ev)
+		default:
+			return events
+		}
+	}
+}
+
+func TestSyncStore_Watch(t *testing.T) {
+	t.Parallel()
+
+	var s dot.SyncStore[string, int]
+	ch := s.Watch(t.Context(), "a")
+
+	s.Put("a", 1)
+	s.Put("b", 2)
+	s.Put("a", 3)
+	s.Update("a", func(old int, _ bool) (int, bool) { return old + 1, true })
+	s.Del("a")
+	s.Del("a")
+
+	assert.Equal(t, []dot.StoreEvent[string, int]{
+		{Kind: dot.StoreEventPut, Key: "a", New: 1},
+		{Kind: dot.StoreEventUpdate, Key: "a", Old: 1, New: 3},
+		{Kind: dot.StoreEventUpdate, Key: "a", Old: 3, New: 4},
+		{Kind: dot.StoreEventDelete, Key: "a", Old: 4},
+	}, drainEvents(ch))
+}
+
+func TestSyncStore_WatchAll(t *testing.T) {
+	t.Parallel()
+
+	var s dot.SyncStore[string, int]
+	ch := s.WatchAll(t.Context())
+
+	s.GetOrPut("a", func() int { return 1 })
+	s.Swap("b", 2)
+	s.LoadAndDelete("a")
+	s.DeleteIf(func(string, int) bool { return true })
+
+	assert.Equal(t, []dot.StoreEvent[string, int]{
+		{Kind: dot.StoreEventPut, Key: "a", New: 1},
+		{Kind: dot.StoreEventPut, Key: "b", New: 2},
+		{Kind: dot.StoreEventDelete, Key: "a", Old: 1},
+		{Kind: dot.StoreEventDelete, Key: "b", Old: 2},
+	}, drainEvents(ch))
+}
+
+func TestSyncStore_WatchDropPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		policy dot.DropPolicy
+		expect []int
+	}{
+		{name: "drop newest", policy: dot.DropNewest, expect: []int{0, 1}},
+		{name: "drop oldest", policy: dot.DropOldest, expect: []int{3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var s dot.SyncStore[string, int]
+			ch := s.Watch(t.Context(), "a", dot.WithWatchBuffer(2), dot.WithWatchDropPolicy(tt.policy))
+			for i := range 5 {
+				s.Put("a", i)
+			}
+
+			var got []int
+			for _, ev := range drainEvents(ch) {
+				got = append(got, ev.New)
+			}
+			assert.Equal(t, tt.expect, got)
+		})
+	}
+}
+
+func TestSyncStore_WatchCancel(t *testing.T) {
+	t.Parallel()
+
+	var s dot.SyncStore[string, int]
+	ctx, cancel := context.WithCancel(t.Context())
+	ch := s.WatchAll(ctx)
+	s.Put("a", 1)
+	cancel()
+
+	var events []dot.StoreEvent[string, int]
+	for ev := range ch {
+		events = append(events, ev)
+	}
+	assert.Len(t, events, 1)
+
+	// store keeps working after unsubscribe
+	s.Put("a", 2)
+	v, _ := s.GetCurrent("a")
+	assert.Equal(t, 2, v)
+}
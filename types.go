@@ -1,3 +1,9 @@
 package dot
 
 type Nothing struct{}
+
+// Pair - two values of possibly different types
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
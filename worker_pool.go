@@ -0,0 +1,168 @@
+package dot
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrPoolClosed - task is submitted after WorkerPool Close
+var ErrPoolClosed = errors.New("worker pool is closed")
+
+// PoolOption - WorkerPool configuration option for NewWorkerPool
+type PoolOption func(cfg *poolConfig)
+
+type poolConfig struct {
+	queueSize     int
+	ordered       bool
+	cancelOnError bool
+}
+
+// WithPoolQueueSize - sets count of submitted tasks waiting for a free worker, Submit blocks when it is reached.
+// Default is the workers count.
+func WithPoolQueueSize(size int) PoolOption {
+	return func(cfg *poolConfig) {
+		cfg.queueSize = size
+	}
+}
+
+// WithPoolOrdered - collects results in order of task submission instead of order of completion
+func WithPoolOrdered() PoolOption {
+	return func(cfg *poolConfig) {
+		cfg.ordered = true
+	}
+}
+
+// WithPoolCancelOnError - cancels the pool context on the first task error, like errgroup does
+func WithPoolCancelOnError() PoolOption {
+	return func(cfg *poolConfig) {
+		cfg.cancelOnError = true
+	}
+}
+
+// WorkerPool - fixed count of workers executing submitted tasks and collecting their results.
+// Task panics are recovered into PanicError results.
+// Tasks taken after the pool context is done are not called and get the context error as result.
+type WorkerPool[T any] struct {
+	cfg     poolConfig
+	ctx     context.Context
+	cancel  context.CancelFunc
+	tasks   chan poolTask[T]
+	results *SyncSlice[Result[T]]
+	workers sync.WaitGroup
+
+	submitMx  sync.Mutex // serializes Submit and Close
+	closed    bool
+	submitted int
+
+	errOnce  sync.Once
+	firstErr error
+}
+
+type poolTask[T any] struct {
+	index int
+	run   func(ctx context.Context) (T, error)
+}
+
+// NewWorkerPool - starts workers (at least 1) executing tasks with context derived from ctx
+func NewWorkerPool[T any](ctx context.Context, workers int, opts ...PoolOption) *WorkerPool[T] {
+	workers = max(workers, 1)
+	cfg := poolConfig{queueSize: workers}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	p := &WorkerPool[T]{
+		cfg:     cfg,
+		tasks:   make(chan poolTask[T], max(cfg.queueSize, 0)),
+		results: NewSyncSlice[Result[T]](0, 0),
+	}
+	p.ctx, p.cancel = context.WithCancel(ctx)
+
+	p.workers.Add(workers)
+	for range workers {
+		go p.work()
+	}
+
+	return p
+}
+
+// Submit - queues task, waiting for space in the queue.
+// Returns ErrPoolClosed after Close and the pool context error if it is done.
+func (p *WorkerPool[T]) Submit(task func(ctx context.Context) (T, error)) error {
+	p.submitMx.Lock()
+	defer p.submitMx.Unlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+	if err := p.ctx.Err(); err != nil {
+		return err
+	}
+
+	if p.cfg.ordered {
+		// placeholder to be set by worker, submissions are serialized so it is the last one on failure
+		p.results.Append(Result[T]{})
+	}
+
+	select {
+	case p.tasks <- poolTask[T]{index: p.submitted, run: task}:
+		p.submitted++
+		return nil
+	case <-p.ctx.Done():
+		if p.cfg.ordered {
+			p.results.Pop()
+		}
+		return p.ctx.Err()
+	}
+}
+
+// Close - forbids submitting, already submitted tasks are still executed. Safe to call several times.
+func (p *WorkerPool[T]) Close() {
+	p.submitMx.Lock()
+	defer p.submitMx.Unlock()
+
+	if !p.closed {
+		p.closed = true
+		close(p.tasks)
+	}
+}
+
+// Wait - closes the pool, waits for all submitted tasks and returns their results with the first task error
+func (p *WorkerPool[T]) Wait() (*SyncSlice[Result[T]], error) {
+	p.Close()
+	p.workers.Wait()
+	p.cancel()
+
+	return p.results, p.firstErr
+}
+
+func (p *WorkerPool[T]) work() {
+	defer p.workers.Done()
+
+	for task := range p.tasks {
+		var res Result[T]
+		if err := p.ctx.Err(); err != nil {
+			res.err = err
+		} else {
+			res = MakeResult(CallRecover(func() (T, error) {
+				return task.run(p.ctx)
+			}))
+		}
+
+		if res.IsErr() {
+			p.errOnce.Do(func() {
+				p.firstErr = res.err
+				if p.cfg.cancelOnError {
+					p.cancel()
+				}
+			})
+		}
+
+		if p.cfg.ordered {
+			p.results.Set(task.index, res)
+		} else {
+			p.results.Append(res)
+		}
+	}
+}
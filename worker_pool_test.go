@@ -0,0 +1,205 @@
+package dot_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirrorru/dot"
+)
+
+func TestWorkerPool_Unordered(t *testing.T) {
+	t.Parallel()
+
+	var active, maxActive atomic.Int32
+	p := dot.NewWorkerPool[int](t.Context(), 3)
+	for i := range 30 {
+		require.NoError(t, p.Submit(func(context.Context) (int, error) {
+			cur := active.Add(1)
+			defer active.Add(-1)
+			for {
+				prev := maxActive.Load()
+				if cur <= prev || maxActive.CompareAndSwap(prev, cur) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			return i, nil
+		}))
+	}
+	results, err := p.Wait()
+	require.NoError(t, err)
+	assert.LessOrEqual(t, maxActive.Load(), int32(3))
+
+	vals := make([]int, 0, results.Len())
+	for res := range results.Seq() {
+		require.False(t, res.IsErr())
+		vals = append(vals, res.Val())
+	}
+	assert.Len(t, vals, 30)
+	for i := range 30 {
+		assert.Contains(t, vals, i)
+	}
+
+	require.ErrorIs(t, p.Submit(func(context.Context) (int, error) { return 0, nil }), dot.ErrPoolClosed)
+	p.Close()
+}
+
+func TestWorkerPool_Ordered(t *testing.T) {
+	t.Parallel()
+
+	p := dot.NewWorkerPool[int](t.Context(), 4, dot.WithPoolOrdered())
+	for i := range 20 {
+		require.NoError(t, p.Submit(func(context.Context) (int, error) {
+			time.Sleep(time.Duration(20-i) * 100 * time.Microsecond)
+			if i == 5 {
+				return 0, assert.AnError
+			}
+			return i * 10, nil
+		}))
+	}
+	results, err := p.Wait()
+	require.ErrorIs(t, err, assert.AnError)
+	require.Equal(t, 20, results.Len())
+	for i, res := range results.Seq2() {
+		if i == 5 {
+			require.ErrorIs(t, res.Err(), assert.AnError)
+			continue
+		}
+		assert.Equal(t, i*10, res.Val())
+	}
+}
+
+func TestWorkerPool_Panic(t *testing.T) {
+	t.Parallel()
+
+	p := dot.NewWorkerPool[int](t.Context(), 2)
+	require.NoError(t, p.Submit(func(context.Context) (int, error) { panic("boom") }))
+	require.NoError(t, p.Submit(func(context.Context) (int, error) { return 1, nil }))
+	results, err := p.Wait()
+
+	var panicErr *dot.PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.Equal(t, 2, results.Len())
+}
+
+func TestWorkerPool_CancelOnError(t *testing.T) {
+	t.Parallel()
+
+	p := dot.NewWorkerPool[int](t.Context(), 1, dot.WithPoolCancelOnError(), dot.WithPoolQueueSize(10))
+	var calls atomic.Int32
+	require.NoError(t, p.Submit(func(context.Context) (int, error) {
+		calls.Add(1)
+		return 0, assert.AnError
+	}))
+	var submitErr error
+	for range 10 {
+		if submitErr = p.Submit(func(context.Context) (int, error) {
+			calls.Add(1)
+			return 1, nil
+		}); submitErr != nil {
+			break
+		}
+	}
+	results, err := p.Wait()
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, int32(1), calls.Load())
+	for i, res := range results.Seq2() {
+		if i > 0 {
+			require.ErrorIs(t, res.Err(), context.Canceled)
+		}
+	}
+	if submitErr != nil {
+		require.ErrorIs(t, submitErr, context.Canceled)
+	}
+}
+
+func TestWorkerPool_Backpressure(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	blocking := func(context.Context) (int, error) {
+		<-release
+		return 0, nil
+	}
+
+	p := dot.NewWorkerPool[int](t.Context(), 1, dot.WithPoolQueueSize(1))
+	require.NoError(t, p.Submit(blocking))
+	require.NoError(t, p.Submit(blocking))
+
+	submitted := make(chan error)
+	go func() {
+		submitted <- p.Submit(blocking)
+	}()
+	select {
+	case <-submitted:
+		t.Fatal("Submit must wait for space in the queue")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	require.NoError(t, <-submitted)
+	results, err := p.Wait()
+	require.NoError(t, err)
+	assert.Equal(t, 3, results.Len())
+}
+
+func TestWorkerPool_ParentCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	release := make(chan struct{})
+	p := dot.NewWorkerPool[int](ctx, 1, dot.WithPoolQueueSize(1), dot.WithPoolOrdered())
+	require.NoError(t, p.Submit(func(ctx context.Context) (int, error) {
+		<-release
+		return 0, ctx.Err()
+	}))
+	require.NoError(t, p.Submit(func(context.Context) (int, error) { return 1, nil }))
+
+	submitted := make(chan error)
+	go func() {
+		submitted <- p.Submit(func(context.Context) (int, error) { return 2, nil })
+	}()
+	cancel()
+	require.ErrorIs(t, <-submitted, context.Canceled)
+	close(release)
+
+	results, err := p.Wait()
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 2, results.Len())
+	for res := range results.Seq() {
+		require.ErrorIs(t, res.Err(), context.Canceled)
+	}
+}
+
+func TestWorkerPool_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	p := dot.NewWorkerPool[int](t.Context(), 4, dot.WithPoolOrdered())
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 50 {
+				assert.NoError(t, p.Submit(func(context.Context) (int, error) { return i, nil }))
+			}
+		}()
+	}
+	wg.Wait()
+
+	results, err := p.Wait()
+	require.NoError(t, err)
+	assert.Equal(t, 400, results.Len())
+	sum := 0
+	for res := range results.Seq() {
+		sum += res.Val()
+	}
+	assert.Equal(t, 8*49*50/2, sum)
+}